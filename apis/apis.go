@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apis contains Kubernetes API groups for the AWS stack.
+package apis
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	computev1alpha2 "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	awsv1alpha2 "github.com/crossplaneio/stack-aws/apis/v1alpha2"
+)
+
+// AddToSchemes may be used to add all resources defined in the project to a
+// Scheme.
+var AddToSchemes runtime.SchemeBuilder
+
+func init() {
+	AddToSchemes = append(AddToSchemes,
+		awsv1alpha2.SchemeBuilder.AddToScheme,
+		computev1alpha2.SchemeBuilder.AddToScheme,
+	)
+}
+
+// AddToScheme adds all resources defined in the project to a Scheme.
+func AddToScheme(s *runtime.Scheme) error {
+	return AddToSchemes.AddToScheme(s)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// GetCondition of this EKSCluster.
+func (e *EKSCluster) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	for _, c := range e.Status.Conditions {
+		if c.Type == ct {
+			return c
+		}
+	}
+	return runtimev1alpha1.Condition{Type: ct, Status: corev1.ConditionUnknown}
+}
+
+// SetConditions of this EKSCluster.
+func (e *EKSCluster) SetConditions(c ...runtimev1alpha1.Condition) {
+	e.Status.SetConditions(c...)
+}
+
+// GetBindingPhase of this EKSCluster.
+func (e *EKSCluster) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return e.Status.GetBindingPhase()
+}
+
+// SetBindingPhase of this EKSCluster.
+func (e *EKSCluster) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	e.Status.SetBindingPhase(p)
+}
+
+// GetClaimReference of this EKSCluster.
+func (e *EKSCluster) GetClaimReference() *corev1.ObjectReference {
+	return e.Spec.ClaimReference
+}
+
+// SetClaimReference of this EKSCluster.
+func (e *EKSCluster) SetClaimReference(r *corev1.ObjectReference) {
+	e.Spec.ClaimReference = r
+}
+
+// GetNonPortableClassReference of this EKSCluster.
+func (e *EKSCluster) GetNonPortableClassReference() *corev1.ObjectReference {
+	return e.Spec.NonPortableClassReference
+}
+
+// SetNonPortableClassReference of this EKSCluster.
+func (e *EKSCluster) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	e.Spec.NonPortableClassReference = r
+}
+
+// GetWriteConnectionSecretToReference of this EKSCluster.
+func (e *EKSCluster) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return e.Spec.WriteConnectionSecretToReference
+}
+
+// SetWriteConnectionSecretToReference of this EKSCluster.
+func (e *EKSCluster) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	e.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetReclaimPolicy of this EKSCluster.
+func (e *EKSCluster) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return e.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this EKSCluster.
+func (e *EKSCluster) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	e.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this EKSCluster.
+func (e *EKSCluster) GetProviderReference() *corev1.ObjectReference {
+	return e.Spec.ProviderReference
+}
+
+// SetProviderReference of this EKSCluster.
+func (e *EKSCluster) SetProviderReference(r *corev1.ObjectReference) {
+	e.Spec.ProviderReference = r
+}
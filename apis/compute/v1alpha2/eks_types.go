@@ -0,0 +1,357 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// ClusterStatusType is the status of an EKS cluster or worker node group, as
+// reported by the EKS API.
+type ClusterStatusType string
+
+// Cluster and worker node group status types.
+const (
+	ClusterStatusCreating ClusterStatusType = "CREATING"
+	ClusterStatusActive   ClusterStatusType = "ACTIVE"
+	ClusterStatusDeleting ClusterStatusType = "DELETING"
+	ClusterStatusFailed   ClusterStatusType = "FAILED"
+	ClusterStatusUpdating ClusterStatusType = "UPDATING"
+)
+
+// MapRole holds a mapping from an AWS IAM role to a Kubernetes user and
+// groups, as consumed by the aws-auth ConfigMap.
+type MapRole struct {
+	RoleARN  string   `json:"rolearn"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+}
+
+// MapUser holds a mapping from an AWS IAM user to a Kubernetes user and
+// groups, as consumed by the aws-auth ConfigMap.
+type MapUser struct {
+	UserARN  string   `json:"userarn"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+}
+
+// WorkerMode determines how an EKSCluster's worker capacity is provisioned.
+type WorkerMode string
+
+// Worker provisioning modes.
+const (
+	// WorkerModeSelfManaged provisions a self-managed auto-scaling group of
+	// worker nodes via a CloudFormation stack. This is the default, and the
+	// only mode available prior to the introduction of WorkerMode.
+	WorkerModeSelfManaged WorkerMode = "SelfManaged"
+
+	// WorkerModeManagedNodeGroup provisions worker nodes via the EKS managed
+	// node group API.
+	WorkerModeManagedNodeGroup WorkerMode = "ManagedNodeGroup"
+
+	// WorkerModeFargate provisions pod capacity via an EKS Fargate profile,
+	// rather than EC2 worker nodes.
+	WorkerModeFargate WorkerMode = "Fargate"
+)
+
+// LaunchTemplateSpec identifies an EC2 launch template used to customize the
+// instances in a managed node group.
+type LaunchTemplateSpec struct {
+	// ID of the launch template.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name of the launch template.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Version of the launch template to use. Defaults to the template's
+	// default version.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// Taint is a Kubernetes node taint applied to every node in a managed node
+// group.
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// NodeGroupSpec configures an EKS managed node group, used when WorkerMode is
+// WorkerModeManagedNodeGroup.
+type NodeGroupSpec struct {
+	// NodeGroupName is the name of the managed node group.
+	NodeGroupName string `json:"nodeGroupName,omitempty"`
+
+	// NodeRole is the ARN of the IAM role assumed by worker nodes in this
+	// node group.
+	NodeRole string `json:"nodeRole,omitempty"`
+
+	// SubnetIDs the node group's instances are launched into.
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// InstanceTypes offered by the node group. Required unless LaunchTemplate
+	// specifies an instance type.
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
+	// AMIType is the type of AMI associated with the node group.
+	// +optional
+	AMIType string `json:"amiType,omitempty"`
+
+	// DiskSize in GiB for each worker node's root volume.
+	// +optional
+	DiskSize *int64 `json:"diskSize,omitempty"`
+
+	// MinSize is the minimum number of worker nodes.
+	MinSize *int64 `json:"minSize,omitempty"`
+
+	// DesiredSize is the desired number of worker nodes.
+	DesiredSize *int64 `json:"desiredSize,omitempty"`
+
+	// MaxSize is the maximum number of worker nodes.
+	MaxSize *int64 `json:"maxSize,omitempty"`
+
+	// Labels applied to every node in the node group.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints for the nodes in the node group. These are not sent to EKS when
+	// the node group is created, so its nodes do not carry them.
+	// +optional
+	Taints []Taint `json:"taints,omitempty"`
+
+	// LaunchTemplate used to launch the node group's instances, in place of
+	// InstanceTypes and AMIType.
+	// +optional
+	LaunchTemplate *LaunchTemplateSpec `json:"launchTemplate,omitempty"`
+}
+
+// FargateSelector selects the pods a Fargate profile provisions capacity for.
+type FargateSelector struct {
+	// Namespace the selector matches.
+	Namespace string `json:"namespace"`
+
+	// Labels the selector matches. Pods must match every label to be
+	// scheduled onto Fargate by this selector.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// FargateProfileSpec configures an EKS Fargate profile, used when WorkerMode
+// is WorkerModeFargate.
+type FargateProfileSpec struct {
+	// ProfileName is the name of the Fargate profile.
+	ProfileName string `json:"profileName,omitempty"`
+
+	// PodExecutionRoleARN is the ARN of the IAM role Fargate assumes to run
+	// pods scheduled by this profile.
+	PodExecutionRoleARN string `json:"podExecutionRoleArn,omitempty"`
+
+	// SubnetIDs the profile's pods are launched into.
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// Selectors determine which pods are scheduled onto Fargate by this
+	// profile.
+	Selectors []FargateSelector `json:"selectors,omitempty"`
+}
+
+// WorkerNodesSpec configures the self-managed, CloudFormation-provisioned
+// worker node group that backs an EKSCluster.
+type WorkerNodesSpec struct {
+	// KeyName is the EC2 SSH key name to use for worker nodes.
+	KeyName string `json:"keyName,omitempty"`
+
+	// NodeImageID is the worker node AMI ID.
+	NodeImageID string `json:"nodeImageId,omitempty"`
+
+	// NodeInstanceType is the EC2 instance type for worker nodes.
+	NodeInstanceType string `json:"nodeInstanceType,omitempty"`
+
+	// NodeAutoScalingGroupMinSize is the minimum number of worker nodes.
+	// +optional
+	NodeAutoScalingGroupMinSize *int64 `json:"nodeAutoScalingGroupMinSize,omitempty"`
+
+	// NodeAutoScalingGroupDesiredCapacity is the desired number of worker
+	// nodes.
+	// +optional
+	NodeAutoScalingGroupDesiredCapacity *int64 `json:"nodeAutoScalingGroupDesiredCapacity,omitempty"`
+
+	// NodeAutoScalingGroupMaxSize is the maximum number of worker nodes.
+	// +optional
+	NodeAutoScalingGroupMaxSize *int64 `json:"nodeAutoScalingGroupMaxSize,omitempty"`
+
+	// NodeVolumeSize is the size in GiB of the root volume attached to each
+	// worker node.
+	// +optional
+	NodeVolumeSize *int64 `json:"nodeVolumeSize,omitempty"`
+
+	// BootstrapArguments passed to the worker node bootstrap script.
+	BootstrapArguments string `json:"bootstrapArguments,omitempty"`
+
+	// NodeGroupName is the name given to the worker node CloudFormation
+	// stack's auto-scaling group.
+	NodeGroupName string `json:"nodeGroupName,omitempty"`
+
+	// ClusterControlPlaneSecurityGroup is the security group used by the
+	// cluster control plane, required so worker nodes can be authorized to
+	// communicate with it.
+	ClusterControlPlaneSecurityGroup string `json:"clusterControlPlaneSecurityGroup,omitempty"`
+
+	// DrainTimeout bounds how long the reconciler waits, from when draining
+	// began, for the worker nodes to drain. Once it has passed, each drain
+	// attempt that still finds pods to evict fails with an error, and
+	// deletion does not proceed.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// IgnoreDaemonSets causes the drain to skip eviction of DaemonSet-managed
+	// pods, which would otherwise block every drain indefinitely.
+	// +optional
+	IgnoreDaemonSets bool `json:"ignoreDaemonSets,omitempty"`
+
+	// DeleteEmptyDirData causes the drain to evict pods using emptyDir
+	// volumes, discarding their local data.
+	// +optional
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData,omitempty"`
+}
+
+// EKSClusterSpec specifies the desired state of an EKSCluster.
+type EKSClusterSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+
+	Region            string   `json:"region"`
+	ClusterVersion    string   `json:"clusterVersion,omitempty"`
+	RoleARN           string   `json:"roleARN,omitempty"`
+	VPCID             string   `json:"vpcId,omitempty"`
+	SubnetIDs         []string `json:"subnetIds,omitempty"`
+	SecurityGroupIDs  []string `json:"securityGroupIds,omitempty"`
+	MapRoles          []MapRole `json:"mapRoles,omitempty"`
+	MapUsers          []MapUser `json:"mapUsers,omitempty"`
+
+	// WorkerMode determines how this cluster's worker capacity is
+	// provisioned. Defaults to WorkerModeSelfManaged.
+	// +optional
+	// +kubebuilder:validation:Enum=SelfManaged;ManagedNodeGroup;Fargate
+	WorkerMode WorkerMode `json:"workerMode,omitempty"`
+
+	// WorkerNodes configures the cluster's worker nodes. Only used when
+	// WorkerMode is WorkerModeSelfManaged.
+	// +optional
+	WorkerNodes WorkerNodesSpec `json:"workerNodes,omitempty"`
+
+	// NodeGroup configures the cluster's managed node group. Only used when
+	// WorkerMode is WorkerModeManagedNodeGroup.
+	// +optional
+	NodeGroup NodeGroupSpec `json:"nodeGroup,omitempty"`
+
+	// FargateProfile configures the cluster's Fargate profile. Only used
+	// when WorkerMode is WorkerModeFargate.
+	// +optional
+	FargateProfile FargateProfileSpec `json:"fargateProfile,omitempty"`
+}
+
+// ClusterPhase identifies a discrete step of EKSCluster reconciliation, so
+// that progress is observable (e.g. via kubectl describe) and so that each
+// step can be implemented and tested in isolation.
+type ClusterPhase string
+
+// Cluster reconciliation phases, in the order a cluster normally moves
+// through them.
+const (
+	PhaseCreatingControlPlane  ClusterPhase = "CreatingControlPlane"
+	PhaseWaitingForControlPlane ClusterPhase = "WaitingForControlPlane"
+	PhaseCreatingWorkers       ClusterPhase = "CreatingWorkers"
+	PhaseWaitingForWorkers     ClusterPhase = "WaitingForWorkers"
+	PhaseApplyingAuth          ClusterPhase = "ApplyingAuth"
+	PhasePublishingSecret      ClusterPhase = "PublishingSecret"
+	PhaseReady                 ClusterPhase = "Ready"
+)
+
+// EKSClusterStatus represents the observed state of an EKSCluster.
+type EKSClusterStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	ClusterName           string            `json:"clusterName,omitempty"`
+	State                 ClusterStatusType `json:"state,omitempty"`
+	ClusterVersion        string            `json:"clusterVersion,omitempty"`
+	Endpoint              string            `json:"endpoint,omitempty"`
+	CloudFormationStackID string            `json:"cloudFormationStackID,omitempty"`
+
+	// Phase is the cluster's current reconciliation phase.
+	// +optional
+	Phase ClusterPhase `json:"phase,omitempty"`
+
+	// NodeGroupARN is the ARN of the managed node group backing this
+	// cluster, set when WorkerMode is WorkerModeManagedNodeGroup.
+	// +optional
+	NodeGroupARN string `json:"nodeGroupARN,omitempty"`
+
+	// FargateProfileARN is the ARN of the Fargate profile backing this
+	// cluster, set when WorkerMode is WorkerModeFargate.
+	// +optional
+	FargateProfileARN string `json:"fargateProfileARN,omitempty"`
+
+	// WorkerRoleARN is the IAM role ARN of the cluster's worker capacity,
+	// mapped into the aws-auth ConfigMap so worker nodes can join the
+	// cluster. Empty for worker modes with no such role (e.g. Fargate).
+	// +optional
+	WorkerRoleARN string `json:"workerRoleARN,omitempty"`
+
+	// ConnectionTokenExpiresAt records when the aws-iam-authenticator token
+	// most recently published to the connection secret expires, so we know
+	// when to mint and publish a new one.
+	// +optional
+	ConnectionTokenExpiresAt *metav1.Time `json:"connectionTokenExpiresAt,omitempty"`
+
+	// DrainStartedAt records when worker node draining began, so repeated
+	// non-blocking drain passes across reconciles can be timed out
+	// collectively rather than individually.
+	// +optional
+	DrainStartedAt *metav1.Time `json:"drainStartedAt,omitempty"`
+
+	// AWSAuthConfigMapChecksum is a checksum of the last aws-auth ConfigMap
+	// we successfully applied to the downstream cluster, used to avoid
+	// re-applying it when MapRoles/MapUsers haven't changed.
+	// +optional
+	AWSAuthConfigMapChecksum string `json:"awsAuthConfigMapChecksum,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EKSCluster is a managed resource that represents an AWS Elastic Kubernetes
+// Service cluster.
+type EKSCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EKSClusterSpec   `json:"spec,omitempty"`
+	Status EKSClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EKSClusterList contains a list of EKSCluster.
+type EKSClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EKSCluster `json:"items"`
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A SecretKeySelector references a key of a Secret in an arbitrary
+// namespace.
+type SecretKeySelector struct {
+	corev1.SecretReference `json:",inline"`
+
+	// Key within the referenced Secret.
+	Key string `json:"key"`
+}
+
+// ProviderSpec defines the desired state of a Provider.
+type ProviderSpec struct {
+	// Secret containing the AWS credentials (an INI-formatted credentials
+	// file) to use.
+	Secret SecretKeySelector `json:"credentialsSecretRef"`
+
+	Region string `json:"region"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Provider configures an AWS 'provider', i.e. a credential set and region
+// used to authenticate AWS API calls on behalf of managed resources.
+type Provider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProviderSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderList contains a list of Provider.
+type ProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Provider `json:"items"`
+}
+
+// GetSecretKeySelector returns the selector for this Provider's referenced
+// credentials Secret key.
+func (p *Provider) GetSecretKeySelector() *SecretKeySelector {
+	return &p.Spec.Secret
+}
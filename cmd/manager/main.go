@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command manager runs the AWS stack's controllers and validating webhooks.
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	"github.com/crossplaneio/stack-aws/apis"
+	computecontroller "github.com/crossplaneio/stack-aws/pkg/controller/compute"
+	computewebhook "github.com/crossplaneio/stack-aws/pkg/webhook/compute"
+)
+
+var log = logf.Log.WithName("entrypoint")
+
+func main() {
+	var (
+		app   = kingpin.New(filepath.Base(os.Args[0]), "An AWS Crossplane Stack.").DefaultEnvars()
+		debug = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+
+		syncPeriod = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+
+		webhookServiceName      = app.Flag("webhook-service-name", "Name of the Service that fronts the webhook server.").Default("stack-aws").String()
+		webhookServiceNamespace = app.Flag("webhook-service-namespace", "Namespace of the Service that fronts the webhook server.").Default("crossplane-system").String()
+		webhookCABundlePath     = app.Flag("webhook-ca-bundle-path", "Path to the PEM encoded CA bundle the API server uses to validate the webhook server's certificate.").Default("/tmp/k8s-webhook-server/serving-certs/ca.crt").String()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	logf.SetLogger(logf.ZapLogger(*debug))
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "Cannot get config")
+		os.Exit(1)
+	}
+
+	mgr, err := manager.New(cfg, manager.Options{SyncPeriod: syncPeriod})
+	if err != nil {
+		log.Error(err, "Cannot create manager")
+		os.Exit(1)
+	}
+
+	log.Info("Adding schemes to manager")
+	if err := apis.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "Cannot add APIs to scheme")
+		os.Exit(1)
+	}
+
+	log.Info("Adding controllers to manager")
+	if err := computecontroller.Add(mgr); err != nil {
+		log.Error(err, "Cannot add EKS controller to manager")
+		os.Exit(1)
+	}
+
+	log.Info("Adding webhooks to manager")
+	caBundle, err := ioutil.ReadFile(*webhookCABundlePath)
+	if err != nil {
+		log.Error(err, "Cannot read webhook CA bundle")
+		os.Exit(1)
+	}
+	if err := computewebhook.Add(mgr, caBundle, *webhookServiceNamespace, *webhookServiceName); err != nil {
+		log.Error(err, "Cannot add EKS validating webhook to manager")
+		os.Exit(1)
+	}
+
+	log.Info("Starting the manager")
+	log.Error(mgr.Start(signals.SetupSignalHandler()), "Cannot start controller manager")
+}
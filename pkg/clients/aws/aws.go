@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aws provides utilities for constructing AWS SDK configuration from
+// a Crossplane Provider resource.
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/go-ini/ini"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsv1alpha2 "github.com/crossplaneio/stack-aws/apis/v1alpha2"
+)
+
+// Config returns an aws.Config that uses the credentials and region
+// configured on the supplied Provider.
+func Config(ctx context.Context, c client.Client, p *awsv1alpha2.Provider) (*aws.Config, error) {
+	sel := p.GetSecretKeySelector()
+
+	data, err := getSecretData(ctx, c, p.GetNamespace(), sel)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials secret")
+	}
+
+	profile, err := ini.Load(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse credentials file")
+	}
+
+	section, err := profile.GetSection(sel.Key)
+	if err != nil {
+		section = profile.Section("")
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load default AWS config")
+	}
+
+	cfg.Region = p.Spec.Region
+	cfg.Credentials = credentials.NewStaticCredentialsProvider(
+		section.Key("aws_access_key_id").String(),
+		section.Key("aws_secret_access_key").String(),
+		section.Key("aws_session_token").String(),
+	)
+
+	return &cfg, nil
+}
+
+func getSecretData(ctx context.Context, c client.Client, namespace string, sel *awsv1alpha2.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: namespace, Name: sel.Name}
+	if sel.Namespace != "" {
+		n.Namespace = sel.Namespace
+	}
+
+	if err := c.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, "cannot get secret")
+	}
+
+	return s.Data[sel.Key], nil
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eks implements an AWS EKS client used by the compute controller.
+package eks
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+)
+
+// ctx is used for calls made to the AWS API, none of which take a context of
+// their own from their caller. This mirrors the rest of the compute
+// controller, which is not context-aware either.
+var ctx = context.Background()
+
+// Cluster represents the state of an EKS cluster as last observed from the
+// EKS API.
+type Cluster struct {
+	Name           string
+	ARN            string
+	Status         v1alpha2.ClusterStatusType
+	ClusterVersion string
+	Endpoint       string
+	CA             string
+}
+
+// ClusterWorkers represents the state of the CloudFormation stack that backs
+// a cluster's self-managed worker nodes.
+type ClusterWorkers struct {
+	WorkerStackID string
+	WorkerARN     string
+	WorkersStatus cftypes.StackStatus
+	WorkerReason  string
+}
+
+// NodeGroup represents the state of an EKS managed node group.
+type NodeGroup struct {
+	NodeGroupName string
+	NodeGroupARN  string
+	Status        ekstypes.NodegroupStatus
+}
+
+// FargateProfile represents the state of an EKS Fargate profile.
+type FargateProfile struct {
+	ProfileName string
+	ProfileARN  string
+	Status      ekstypes.FargateProfileStatus
+}
+
+// Client defines the EKS operations used by the compute controller.
+type Client interface {
+	Create(name string, spec v1alpha2.EKSClusterSpec) (*Cluster, error)
+	Get(name string) (*Cluster, error)
+	Delete(name string) error
+
+	CreateWorkerNodes(name, clusterVersion string, spec v1alpha2.EKSClusterSpec) (*ClusterWorkers, error)
+	GetWorkerNodes(stackID string) (*ClusterWorkers, error)
+	DeleteWorkerNodes(stackID string) error
+
+	CreateNodeGroup(clusterName string, spec v1alpha2.NodeGroupSpec) (*NodeGroup, error)
+	GetNodeGroup(clusterName, nodeGroupName string) (*NodeGroup, error)
+	DeleteNodeGroup(clusterName, nodeGroupName string) error
+
+	CreateFargateProfile(clusterName string, spec v1alpha2.FargateProfileSpec) (*FargateProfile, error)
+	GetFargateProfile(clusterName, profileName string) (*FargateProfile, error)
+	DeleteFargateProfile(clusterName, profileName string) error
+
+	ConnectionToken(name string) (string, error)
+}
+
+// client is a Client backed by the real AWS EKS, CloudFormation and STS
+// APIs.
+type client struct {
+	eks     *awseks.Client
+	cf      *cloudformation.Client
+	sts     *sts.Client
+	presign *sts.PresignClient
+}
+
+// NewClient creates a new EKS client backed by the given AWS configuration.
+func NewClient(config aws.Config) Client {
+	stsClient := sts.NewFromConfig(config)
+	return &client{
+		eks:     awseks.NewFromConfig(config),
+		cf:      cloudformation.NewFromConfig(config),
+		sts:     stsClient,
+		presign: sts.NewPresignClient(stsClient),
+	}
+}
+
+// Create a new EKS cluster control plane.
+func (c *client) Create(name string, spec v1alpha2.EKSClusterSpec) (*Cluster, error) {
+	rsp, err := c.eks.CreateCluster(ctx, &awseks.CreateClusterInput{
+		Name:    aws.String(name),
+		Version: aws.String(spec.ClusterVersion),
+		RoleArn: aws.String(spec.RoleARN),
+		ResourcesVpcConfig: &ekstypes.VpcConfigRequest{
+			SubnetIds:        spec.SubnetIDs,
+			SecurityGroupIds: spec.SecurityGroupIDs,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create cluster")
+	}
+
+	return fromAWSCluster(rsp.Cluster), nil
+}
+
+// Get the named EKS cluster control plane.
+func (c *client) Get(name string) (*Cluster, error) {
+	rsp, err := c.eks.DescribeCluster(ctx, &awseks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot describe cluster")
+	}
+
+	return fromAWSCluster(rsp.Cluster), nil
+}
+
+// Delete the named EKS cluster control plane.
+func (c *client) Delete(name string) error {
+	_, err := c.eks.DeleteCluster(ctx, &awseks.DeleteClusterInput{Name: aws.String(name)})
+	return errors.Wrap(err, "cannot delete cluster")
+}
+
+// CreateWorkerNodes provisions a self-managed worker node group by standing
+// up AWS's own CloudFormation worker node stack template, parameterised
+// from spec.WorkerNodes and the cluster's name. clusterVersion is not passed
+// to the stack.
+func (c *client) CreateWorkerNodes(name, clusterVersion string, spec v1alpha2.EKSClusterSpec) (*ClusterWorkers, error) {
+	rsp, err := c.cf.CreateStack(ctx, &cloudformation.CreateStackInput{
+		StackName:    aws.String(name + "-worker-nodes"),
+		TemplateBody: aws.String(workerStackTemplate),
+		Parameters:   workerStackParameters(name, clusterVersion, spec),
+		Capabilities: []cftypes.Capability{cftypes.CapabilityCapabilityNamedIam},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create worker node stack")
+	}
+
+	return &ClusterWorkers{WorkerStackID: aws.ToString(rsp.StackId)}, nil
+}
+
+// GetWorkerNodes returns the status of a worker node CloudFormation stack.
+func (c *client) GetWorkerNodes(stackID string) (*ClusterWorkers, error) {
+	rsp, err := c.cf.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{StackName: aws.String(stackID)})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot describe worker node stack")
+	}
+
+	if len(rsp.Stacks) == 0 {
+		return nil, errors.New("worker node stack not found")
+	}
+
+	stack := rsp.Stacks[0]
+	w := &ClusterWorkers{
+		WorkerStackID: stackID,
+		WorkersStatus: stack.StackStatus,
+		WorkerReason:  aws.ToString(stack.StackStatusReason),
+	}
+	for _, o := range stack.Outputs {
+		if aws.ToString(o.OutputKey) == "NodeInstanceRole" {
+			w.WorkerARN = aws.ToString(o.OutputValue)
+		}
+	}
+	return w, nil
+}
+
+// DeleteWorkerNodes tears down a worker node CloudFormation stack.
+func (c *client) DeleteWorkerNodes(stackID string) error {
+	_, err := c.cf.DeleteStack(ctx, &cloudformation.DeleteStackInput{StackName: aws.String(stackID)})
+	return errors.Wrap(err, "cannot delete worker node stack")
+}
+
+// CreateNodeGroup provisions an EKS managed node group.
+func (c *client) CreateNodeGroup(clusterName string, spec v1alpha2.NodeGroupSpec) (*NodeGroup, error) {
+	var launchTemplate *ekstypes.LaunchTemplateSpecification
+	if lt := spec.LaunchTemplate; lt != nil {
+		launchTemplate = &ekstypes.LaunchTemplateSpecification{}
+		if lt.ID != "" {
+			launchTemplate.Id = aws.String(lt.ID)
+		}
+		if lt.Name != "" {
+			launchTemplate.Name = aws.String(lt.Name)
+		}
+		if lt.Version != "" {
+			launchTemplate.Version = aws.String(lt.Version)
+		}
+	}
+
+	rsp, err := c.eks.CreateNodegroup(ctx, &awseks.CreateNodegroupInput{
+		ClusterName:    aws.String(clusterName),
+		NodegroupName:  aws.String(spec.NodeGroupName),
+		NodeRole:       aws.String(spec.NodeRole),
+		Subnets:        spec.SubnetIDs,
+		InstanceTypes:  spec.InstanceTypes,
+		AmiType:        ekstypes.AMITypes(spec.AMIType),
+		DiskSize:       int32Value(spec.DiskSize),
+		Labels:         spec.Labels,
+		LaunchTemplate: launchTemplate,
+		ScalingConfig: &ekstypes.NodegroupScalingConfig{
+			MinSize:     int32Value(spec.MinSize),
+			DesiredSize: int32Value(spec.DesiredSize),
+			MaxSize:     int32Value(spec.MaxSize),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create node group")
+	}
+
+	return fromAWSNodeGroup(rsp.Nodegroup), nil
+}
+
+// GetNodeGroup returns the state of a managed node group.
+func (c *client) GetNodeGroup(clusterName, nodeGroupName string) (*NodeGroup, error) {
+	rsp, err := c.eks.DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(nodeGroupName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot describe node group")
+	}
+
+	return fromAWSNodeGroup(rsp.Nodegroup), nil
+}
+
+// DeleteNodeGroup tears down a managed node group.
+func (c *client) DeleteNodeGroup(clusterName, nodeGroupName string) error {
+	_, err := c.eks.DeleteNodegroup(ctx, &awseks.DeleteNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(nodeGroupName),
+	})
+	return errors.Wrap(err, "cannot delete node group")
+}
+
+// CreateFargateProfile provisions an EKS Fargate profile.
+func (c *client) CreateFargateProfile(clusterName string, spec v1alpha2.FargateProfileSpec) (*FargateProfile, error) {
+	rsp, err := c.eks.CreateFargateProfile(ctx, &awseks.CreateFargateProfileInput{
+		ClusterName:         aws.String(clusterName),
+		FargateProfileName:  aws.String(spec.ProfileName),
+		PodExecutionRoleArn: aws.String(spec.PodExecutionRoleARN),
+		Subnets:             spec.SubnetIDs,
+		Selectors:           fromFargateSelectors(spec.Selectors),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create fargate profile")
+	}
+
+	return fromAWSFargateProfile(rsp.FargateProfile), nil
+}
+
+// GetFargateProfile returns the state of a Fargate profile.
+func (c *client) GetFargateProfile(clusterName, profileName string) (*FargateProfile, error) {
+	rsp, err := c.eks.DescribeFargateProfile(ctx, &awseks.DescribeFargateProfileInput{
+		ClusterName:        aws.String(clusterName),
+		FargateProfileName: aws.String(profileName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot describe fargate profile")
+	}
+
+	return fromAWSFargateProfile(rsp.FargateProfile), nil
+}
+
+// DeleteFargateProfile tears down a Fargate profile.
+func (c *client) DeleteFargateProfile(clusterName, profileName string) error {
+	_, err := c.eks.DeleteFargateProfile(ctx, &awseks.DeleteFargateProfileInput{
+		ClusterName:        aws.String(clusterName),
+		FargateProfileName: aws.String(profileName),
+	})
+	return errors.Wrap(err, "cannot delete fargate profile")
+}
+
+// clusterIDHeader is the HTTP header aws-iam-authenticator looks for on a
+// presigned GetCallerIdentity request to determine which cluster a bearer
+// token is valid for.
+const clusterIDHeader = "x-k8s-aws-id"
+
+// ConnectionToken returns a presigned aws-iam-authenticator bearer token for
+// the named cluster.
+func (c *client) ConnectionToken(name string) (string, error) {
+	rsp, err := c.presign.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(po *sts.PresignOptions) {
+		po.ClientOptions = append(po.ClientOptions, sts.WithAPIOptions(smithyhttp.AddHeaderValue(clusterIDHeader, name)))
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "cannot presign token request")
+	}
+
+	return tokenPrefix + encodeToken(rsp.URL), nil
+}
+
+func fromAWSNodeGroup(n *ekstypes.Nodegroup) *NodeGroup {
+	if n == nil {
+		return &NodeGroup{}
+	}
+
+	return &NodeGroup{
+		NodeGroupName: aws.ToString(n.NodegroupName),
+		NodeGroupARN:  aws.ToString(n.NodegroupArn),
+		Status:        n.Status,
+	}
+}
+
+func fromAWSFargateProfile(p *ekstypes.FargateProfile) *FargateProfile {
+	if p == nil {
+		return &FargateProfile{}
+	}
+
+	return &FargateProfile{
+		ProfileName: aws.ToString(p.FargateProfileName),
+		ProfileARN:  aws.ToString(p.FargateProfileArn),
+		Status:      p.Status,
+	}
+}
+
+func fromFargateSelectors(selectors []v1alpha2.FargateSelector) []ekstypes.FargateProfileSelector {
+	out := make([]ekstypes.FargateProfileSelector, len(selectors))
+	for i, s := range selectors {
+		out[i] = ekstypes.FargateProfileSelector{
+			Namespace: aws.String(s.Namespace),
+			Labels:    s.Labels,
+		}
+	}
+	return out
+}
+
+func int32Value(i *int64) *int32 {
+	if i == nil {
+		return nil
+	}
+	v := int32(*i)
+	return &v
+}
+
+func fromAWSCluster(c *ekstypes.Cluster) *Cluster {
+	if c == nil {
+		return &Cluster{}
+	}
+
+	out := &Cluster{
+		Name:           aws.ToString(c.Name),
+		ARN:            aws.ToString(c.Arn),
+		Status:         v1alpha2.ClusterStatusType(c.Status),
+		ClusterVersion: aws.ToString(c.Version),
+		Endpoint:       aws.ToString(c.Endpoint),
+	}
+	if c.CertificateAuthority != nil {
+		out.CA = aws.ToString(c.CertificateAuthority.Data)
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a mock implementation of eks.Client for use in tests.
+package fake
+
+import (
+	"github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	"github.com/crossplaneio/stack-aws/pkg/clients/eks"
+)
+
+// MockEKSClient is a mock implementation of eks.Client.
+type MockEKSClient struct {
+	MockCreate func(string, v1alpha2.EKSClusterSpec) (*eks.Cluster, error)
+	MockGet    func(string) (*eks.Cluster, error)
+	MockDelete func(string) error
+
+	MockCreateWorkerNodes func(string, string, v1alpha2.EKSClusterSpec) (*eks.ClusterWorkers, error)
+	MockGetWorkerNodes    func(string) (*eks.ClusterWorkers, error)
+	MockDeleteWorkerNodes func(string) error
+
+	MockCreateNodeGroup func(string, v1alpha2.NodeGroupSpec) (*eks.NodeGroup, error)
+	MockGetNodeGroup    func(string, string) (*eks.NodeGroup, error)
+	MockDeleteNodeGroup func(string, string) error
+
+	MockCreateFargateProfile func(string, v1alpha2.FargateProfileSpec) (*eks.FargateProfile, error)
+	MockGetFargateProfile    func(string, string) (*eks.FargateProfile, error)
+	MockDeleteFargateProfile func(string, string) error
+
+	MockConnectionToken func(string) (string, error)
+}
+
+// Create the cluster.
+func (m *MockEKSClient) Create(name string, spec v1alpha2.EKSClusterSpec) (*eks.Cluster, error) {
+	return m.MockCreate(name, spec)
+}
+
+// Get the cluster.
+func (m *MockEKSClient) Get(name string) (*eks.Cluster, error) {
+	return m.MockGet(name)
+}
+
+// Delete the cluster.
+func (m *MockEKSClient) Delete(name string) error {
+	return m.MockDelete(name)
+}
+
+// CreateWorkerNodes for the cluster.
+func (m *MockEKSClient) CreateWorkerNodes(name, clusterVersion string, spec v1alpha2.EKSClusterSpec) (*eks.ClusterWorkers, error) {
+	return m.MockCreateWorkerNodes(name, clusterVersion, spec)
+}
+
+// GetWorkerNodes for the cluster.
+func (m *MockEKSClient) GetWorkerNodes(stackID string) (*eks.ClusterWorkers, error) {
+	return m.MockGetWorkerNodes(stackID)
+}
+
+// DeleteWorkerNodes for the cluster.
+func (m *MockEKSClient) DeleteWorkerNodes(stackID string) error {
+	return m.MockDeleteWorkerNodes(stackID)
+}
+
+// CreateNodeGroup for the cluster.
+func (m *MockEKSClient) CreateNodeGroup(clusterName string, spec v1alpha2.NodeGroupSpec) (*eks.NodeGroup, error) {
+	return m.MockCreateNodeGroup(clusterName, spec)
+}
+
+// GetNodeGroup for the cluster.
+func (m *MockEKSClient) GetNodeGroup(clusterName, nodeGroupName string) (*eks.NodeGroup, error) {
+	return m.MockGetNodeGroup(clusterName, nodeGroupName)
+}
+
+// DeleteNodeGroup for the cluster.
+func (m *MockEKSClient) DeleteNodeGroup(clusterName, nodeGroupName string) error {
+	return m.MockDeleteNodeGroup(clusterName, nodeGroupName)
+}
+
+// CreateFargateProfile for the cluster.
+func (m *MockEKSClient) CreateFargateProfile(clusterName string, spec v1alpha2.FargateProfileSpec) (*eks.FargateProfile, error) {
+	return m.MockCreateFargateProfile(clusterName, spec)
+}
+
+// GetFargateProfile for the cluster.
+func (m *MockEKSClient) GetFargateProfile(clusterName, profileName string) (*eks.FargateProfile, error) {
+	return m.MockGetFargateProfile(clusterName, profileName)
+}
+
+// DeleteFargateProfile for the cluster.
+func (m *MockEKSClient) DeleteFargateProfile(clusterName, profileName string) error {
+	return m.MockDeleteFargateProfile(clusterName, profileName)
+}
+
+// ConnectionToken for the cluster.
+func (m *MockEKSClient) ConnectionToken(name string) (string, error) {
+	return m.MockConnectionToken(name)
+}
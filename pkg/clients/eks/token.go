@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// tokenPrefix is prepended to every aws-iam-authenticator bearer token, per
+// the k8s-aws-v1 token format.
+const tokenPrefix = "k8s-aws-v1."
+
+// presignedURLExpiration is how long the presigned STS GetCallerIdentity URL
+// embedded in a token remains valid. This matches the window used by
+// aws-iam-authenticator itself, which the API server honours regardless of
+// how long ago the token was minted.
+const presignedURLExpiration = 15 * time.Minute
+
+// amzDateLayout is the time format used by the X-Amz-Date presigned URL
+// query parameter.
+const amzDateLayout = "20060102T150405Z"
+
+func encodeToken(presignedURL string) string {
+	return strings.TrimRight(base64.URLEncoding.EncodeToString([]byte(presignedURL)), "=")
+}
+
+// TokenExpiry returns the expiry time of an aws-iam-authenticator bearer
+// token, derived from the X-Amz-Date and X-Amz-Expires query parameters of
+// its embedded presigned STS URL.
+func TokenExpiry(token string) (time.Time, error) {
+	encoded := strings.TrimPrefix(token, tokenPrefix)
+	if encoded == token {
+		return time.Time{}, errors.New("not a k8s-aws-v1 token")
+	}
+
+	decoded, err := base64.URLEncoding.WithPadding(base64.NoPadding).DecodeString(encoded)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot decode token")
+	}
+
+	u, err := url.Parse(string(decoded))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot parse presigned URL")
+	}
+
+	signedAt, err := time.Parse(amzDateLayout, u.Query().Get("X-Amz-Date"))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot parse X-Amz-Date")
+	}
+
+	expiresIn, err := strconv.Atoi(u.Query().Get("X-Amz-Expires"))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot parse X-Amz-Expires")
+	}
+
+	return signedAt.Add(time.Duration(expiresIn) * time.Second), nil
+}
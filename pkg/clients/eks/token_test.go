@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	signedAt, err := time.Parse(amzDateLayout, "20190912T000000Z")
+	if err != nil {
+		t.Fatalf("time.Parse(...): %s", err)
+	}
+
+	presigned := "https://sts.amazonaws.com/?Action=GetCallerIdentity&Version=2011-06-15&X-Amz-Date=20190912T000000Z&X-Amz-Expires=60"
+	token := tokenPrefix + encodeToken(presigned)
+
+	got, err := TokenExpiry(token)
+	if err != nil {
+		t.Fatalf("TokenExpiry(...): %s", err)
+	}
+
+	want := signedAt.Add(60 * time.Second)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TokenExpiry(...): -want, +got:\n%s", diff)
+	}
+
+	if _, err := TokenExpiry("not-a-token"); err == nil {
+		t.Error("TokenExpiry(\"not-a-token\"): expected an error, got nil")
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	"github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+)
+
+// workerStackTemplate is AWS's own CloudFormation template for provisioning
+// a self-managed EKS worker node group, trimmed of its Mappings (which pin
+// an AMI per region/Kubernetes version and are kept up to date by AWS, not
+// us) in favour of requiring NodeImageId be supplied explicitly. See
+// https://docs.aws.amazon.com/eks/latest/userguide/launch-workers.html.
+const workerStackTemplate = `AWSTemplateFormatVersion: '2010-09-09'
+Description: Amazon EKS - Node Group
+
+Parameters:
+  KeyName:
+    Type: AWS::EC2::KeyPair::KeyName
+  NodeImageId:
+    Type: AWS::EC2::Image::Id
+  NodeInstanceType:
+    Type: String
+    Default: t3.medium
+  NodeAutoScalingGroupMinSize:
+    Type: Number
+    Default: 1
+  NodeAutoScalingGroupDesiredCapacity:
+    Type: Number
+    Default: 3
+  NodeAutoScalingGroupMaxSize:
+    Type: Number
+    Default: 4
+  NodeVolumeSize:
+    Type: Number
+    Default: 20
+  ClusterName:
+    Type: String
+  ClusterControlPlaneSecurityGroup:
+    Type: AWS::EC2::SecurityGroup::Id
+  BootstrapArguments:
+    Type: String
+    Default: ""
+  VpcId:
+    Type: AWS::EC2::VPC::Id
+  Subnets:
+    Type: List<AWS::EC2::Subnet::Id>
+  NodeGroupName:
+    Type: String
+
+Resources:
+  NodeInstanceRole:
+    Type: AWS::IAM::Role
+    Properties:
+      AssumeRolePolicyDocument:
+        Version: '2012-10-17'
+        Statement:
+          - Effect: Allow
+            Principal:
+              Service: ec2.amazonaws.com
+            Action: sts:AssumeRole
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy
+        - arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy
+        - arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly
+
+  NodeInstanceProfile:
+    Type: AWS::IAM::InstanceProfile
+    Properties:
+      Roles:
+        - !Ref NodeInstanceRole
+
+  NodeSecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupDescription: Security group for all nodes in the cluster
+      VpcId: !Ref VpcId
+      Tags:
+        - Key: !Sub kubernetes.io/cluster/${ClusterName}
+          Value: owned
+
+  NodeSecurityGroupIngress:
+    Type: AWS::EC2::SecurityGroupIngress
+    Properties:
+      Description: Allow node to communicate with each other
+      GroupId: !Ref NodeSecurityGroup
+      SourceSecurityGroupId: !Ref NodeSecurityGroup
+      IpProtocol: '-1'
+      FromPort: 0
+      ToPort: 65535
+
+  NodeSecurityGroupFromControlPlaneIngress:
+    Type: AWS::EC2::SecurityGroupIngress
+    Properties:
+      Description: Allow worker Kubelets and pods to receive communication from the cluster control plane
+      GroupId: !Ref NodeSecurityGroup
+      SourceSecurityGroupId: !Ref ClusterControlPlaneSecurityGroup
+      IpProtocol: tcp
+      FromPort: 1025
+      ToPort: 65535
+
+  ControlPlaneEgressToNodeSecurityGroup:
+    Type: AWS::EC2::SecurityGroupEgress
+    Properties:
+      Description: Allow the cluster control plane to communicate with worker Kubelet and pods
+      GroupId: !Ref ClusterControlPlaneSecurityGroup
+      DestinationSecurityGroupId: !Ref NodeSecurityGroup
+      IpProtocol: tcp
+      FromPort: 1025
+      ToPort: 65535
+
+  NodeSecurityGroupFromControlPlaneOn443Ingress:
+    Type: AWS::EC2::SecurityGroupIngress
+    Properties:
+      Description: Allow pods running extension API servers on port 443 to receive communication from cluster control plane
+      GroupId: !Ref NodeSecurityGroup
+      SourceSecurityGroupId: !Ref ClusterControlPlaneSecurityGroup
+      IpProtocol: tcp
+      FromPort: 443
+      ToPort: 443
+
+  ClusterControlPlaneSecurityGroupIngress:
+    Type: AWS::EC2::SecurityGroupIngress
+    Properties:
+      Description: Allow pods to communicate with the cluster API Server
+      GroupId: !Ref ClusterControlPlaneSecurityGroup
+      SourceSecurityGroupId: !Ref NodeSecurityGroup
+      IpProtocol: tcp
+      ToPort: 443
+      FromPort: 443
+
+  NodeLaunchConfig:
+    Type: AWS::AutoScaling::LaunchConfiguration
+    Properties:
+      AssociatePublicIpAddress: true
+      IamInstanceProfile: !Ref NodeInstanceProfile
+      ImageId: !Ref NodeImageId
+      InstanceType: !Ref NodeInstanceType
+      KeyName: !Ref KeyName
+      SecurityGroups:
+        - !Ref NodeSecurityGroup
+      BlockDeviceMappings:
+        - DeviceName: /dev/xvda
+          Ebs:
+            VolumeSize: !Ref NodeVolumeSize
+            VolumeType: gp2
+            DeleteOnTermination: true
+      UserData:
+        Fn::Base64:
+          !Sub |
+            #!/bin/bash
+            set -o xtrace
+            /etc/eks/bootstrap.sh ${ClusterName} ${BootstrapArguments}
+            /opt/aws/bin/cfn-signal --exit-code $? \
+                     --stack  ${AWS::StackName} \
+                     --resource NodeGroup  \
+                     --region ${AWS::Region}
+
+  NodeGroup:
+    Type: AWS::AutoScaling::AutoScalingGroup
+    Properties:
+      DesiredCapacity: !Ref NodeAutoScalingGroupDesiredCapacity
+      LaunchConfigurationName: !Ref NodeLaunchConfig
+      MinSize: !Ref NodeAutoScalingGroupMinSize
+      MaxSize: !Ref NodeAutoScalingGroupMaxSize
+      VPCZoneIdentifier: !Ref Subnets
+      Tags:
+        - Key: Name
+          Value: !Sub ${ClusterName}-${NodeGroupName}-Node
+          PropagateAtLaunch: true
+        - Key: !Sub kubernetes.io/cluster/${ClusterName}
+          Value: owned
+          PropagateAtLaunch: true
+    UpdatePolicy:
+      AutoScalingRollingUpdate:
+        MinInstancesInService: 1
+        MaxBatchSize: 1
+
+Outputs:
+  NodeInstanceRole:
+    Description: The node instance role ARN
+    Value: !GetAtt NodeInstanceRole.Arn
+  NodeSecurityGroup:
+    Description: The security group for the node group
+    Value: !Ref NodeSecurityGroup
+`
+
+// workerStackParameters renders the CloudFormation parameters for the
+// worker node stack template from the supplied cluster name and the
+// EKSCluster's spec. clusterVersion is not used: the template has no
+// Kubernetes version parameter, and NodeImageId selects the worker AMI.
+func workerStackParameters(name, clusterVersion string, spec v1alpha2.EKSClusterSpec) []cftypes.Parameter {
+	w := spec.WorkerNodes
+
+	params := []cftypes.Parameter{
+		param("KeyName", w.KeyName),
+		param("NodeImageId", w.NodeImageID),
+		param("NodeInstanceType", w.NodeInstanceType),
+		param("ClusterName", name),
+		param("ClusterControlPlaneSecurityGroup", w.ClusterControlPlaneSecurityGroup),
+		param("BootstrapArguments", w.BootstrapArguments),
+		param("VpcId", spec.VPCID),
+		param("Subnets", strings.Join(spec.SubnetIDs, ",")),
+		param("NodeGroupName", nodeGroupName(w, name)),
+	}
+
+	if w.NodeAutoScalingGroupMinSize != nil {
+		params = append(params, param("NodeAutoScalingGroupMinSize", strconv.FormatInt(*w.NodeAutoScalingGroupMinSize, 10)))
+	}
+	if w.NodeAutoScalingGroupDesiredCapacity != nil {
+		params = append(params, param("NodeAutoScalingGroupDesiredCapacity", strconv.FormatInt(*w.NodeAutoScalingGroupDesiredCapacity, 10)))
+	}
+	if w.NodeAutoScalingGroupMaxSize != nil {
+		params = append(params, param("NodeAutoScalingGroupMaxSize", strconv.FormatInt(*w.NodeAutoScalingGroupMaxSize, 10)))
+	}
+	if w.NodeVolumeSize != nil {
+		params = append(params, param("NodeVolumeSize", strconv.FormatInt(*w.NodeVolumeSize, 10)))
+	}
+
+	return params
+}
+
+func nodeGroupName(w v1alpha2.WorkerNodesSpec, clusterName string) string {
+	if w.NodeGroupName != "" {
+		return w.NodeGroupName
+	}
+	return clusterName + "-worker-nodes"
+}
+
+func param(key, value string) cftypes.Parameter {
+	return cftypes.Parameter{ParameterKey: aws.String(key), ParameterValue: aws.String(value)}
+}
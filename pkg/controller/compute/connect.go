@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	clientsaws "github.com/crossplaneio/stack-aws/pkg/clients/aws"
+	"github.com/crossplaneio/stack-aws/pkg/clients/eks"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	awsv1alpha2 "github.com/crossplaneio/stack-aws/apis/v1alpha2"
+)
+
+// _connect returns an eks.Client configured using the AWS Provider
+// referenced by the supplied EKSCluster.
+func (r *Reconciler) _connect(cluster *EKSCluster) (eks.Client, error) {
+	p := &awsv1alpha2.Provider{}
+	n := types.NamespacedName{Name: cluster.Spec.ProviderReference.Name, Namespace: cluster.Spec.ProviderReference.Namespace}
+	if err := r.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrap(err, "cannot get provider")
+	}
+
+	cfg, err := clientsaws.Config(ctx, r.Client, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create AWS config")
+	}
+
+	return eks.NewClient(*cfg), nil
+}
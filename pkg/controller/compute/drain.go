@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	"github.com/crossplaneio/stack-aws/pkg/clients/eks"
+)
+
+// ConditionDrainingSucceeded indicates the worker nodes backing an EKSCluster
+// have been cordoned and drained, and it is safe to tear down the worker
+// node CloudFormation stack.
+const ConditionDrainingSucceeded runtimev1alpha1.ConditionType = "DrainingSucceeded"
+
+// defaultDrainTimeout bounds how long _drainWorkers keeps retrying eviction
+// of a node's pods, across however many reconciles it takes, before giving
+// up with an error, when WorkerNodes.DrainTimeout is unset.
+const defaultDrainTimeout = 5 * time.Minute
+
+func drainingSucceeded() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               ConditionDrainingSucceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Successfully drained worker nodes",
+	}
+}
+
+// kubernetesClientForCluster builds a Kubernetes clientset for the
+// downstream cluster represented by the supplied eks.Cluster, authenticating
+// with a freshly minted aws-iam-authenticator token.
+func kubernetesClientForCluster(cluster *eks.Cluster, mg *EKSCluster, client eks.Client) (kubernetes.Interface, error) {
+	token, err := client.ConnectionToken(mg.Status.ClusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get connection token")
+	}
+
+	ca, err := base64.StdEncoding.DecodeString(cluster.CA)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode cluster certificate authority")
+	}
+
+	cfg := &rest.Config{
+		Host:        cluster.Endpoint,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: ca,
+		},
+	}
+
+	return kubernetes.NewForConfig(cfg)
+}
+
+// _drainWorkers cordons every Node in the downstream cluster and evicts its
+// pods, honouring PodDisruptionBudgets, before the worker node
+// CloudFormation stack is torn down. It makes a single non-blocking pass per
+// call - cordoning any schedulable nodes and evicting whatever pods are
+// presently evictable - and returns true once no node has any pods left to
+// evict. Mirror pods, completed pods and ignored DaemonSet pods are not
+// counted.
+// Callers are expected to call it repeatedly, requeuing in between, until it
+// reports the drain complete or returns an error.
+func (r *Reconciler) _drainWorkers(cluster *eks.Cluster, mg *EKSCluster, client eks.Client) (bool, error) {
+	kc, err := kubernetesClientForCluster(cluster, mg, client)
+	if err != nil {
+		return false, err
+	}
+
+	nodes, err := kc.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return false, errors.Wrap(err, "cannot list nodes")
+	}
+
+	timeout := defaultDrainTimeout
+	if mg.Spec.WorkerNodes.DrainTimeout != nil {
+		timeout = mg.Spec.WorkerNodes.DrainTimeout.Duration
+	}
+
+	if mg.Status.DrainStartedAt == nil {
+		t := metav1.Now()
+		mg.Status.DrainStartedAt = &t
+	}
+	timedOut := time.Since(mg.Status.DrainStartedAt.Time) > timeout
+
+	draining := false
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		if !node.Spec.Unschedulable {
+			node.Spec.Unschedulable = true
+			if _, err := kc.CoreV1().Nodes().Update(node); err != nil {
+				return false, errors.Wrapf(err, "cannot cordon node %s", node.Name)
+			}
+		}
+
+		remaining, err := evictNodePods(kc, node.Name, mg.Spec.WorkerNodes.IgnoreDaemonSets, mg.Spec.WorkerNodes.DeleteEmptyDirData)
+		if err != nil {
+			return false, errors.Wrapf(err, "cannot drain node %s", node.Name)
+		}
+		if remaining == 0 {
+			continue
+		}
+		if timedOut {
+			return false, errors.Errorf("timed out waiting for %d pod(s) to be evicted from node %s", remaining, node.Name)
+		}
+		draining = true
+	}
+
+	return !draining, nil
+}
+
+// evictNodePods requests eviction of every evictable pod still scheduled on
+// the named node and returns how many such pods remain. It does not wait for
+// evictions to take effect; PodDisruptionBudget-blocked evictions are left
+// for the next call to retry.
+func evictNodePods(kc kubernetes.Interface, nodeName string, ignoreDaemonSets, deleteEmptyDirData bool) (int, error) {
+	pods, err := kc.CoreV1().Pods(corev1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot list pods")
+	}
+
+	remaining := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		if isMirrorPod(pod) || isCompleted(pod) {
+			continue
+		}
+		if isDaemonSetPod(pod) {
+			if ignoreDaemonSets {
+				continue
+			}
+			return 0, errors.Errorf("pod %s/%s is managed by a DaemonSet; set IgnoreDaemonSets to proceed", pod.Namespace, pod.Name)
+		}
+		if !deleteEmptyDirData && usesEmptyDir(pod) {
+			return 0, errors.Errorf("pod %s/%s uses an emptyDir volume; set DeleteEmptyDirData to proceed", pod.Namespace, pod.Name)
+		}
+
+		remaining++
+
+		eviction := &policyv1beta1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := kc.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction); err != nil &&
+			!kerrors.IsNotFound(err) && !kerrors.IsTooManyRequests(err) {
+			return 0, errors.Wrapf(err, "cannot evict pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+
+	return remaining, nil
+}
+
+func isMirrorPod(pod *corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func isCompleted(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+func usesEmptyDir(pod *corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
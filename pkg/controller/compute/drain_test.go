@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func podOnNode(name, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: node},
+	}
+}
+
+func TestEvictNodePods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// Evictable pods are counted as remaining; eviction is attempted, but we
+	// don't wait to see whether it takes effect.
+	kc := kubernetesfake.NewSimpleClientset(podOnNode("a", "node-1"), podOnNode("b", "node-1"), podOnNode("c", "node-2"))
+	remaining, err := evictNodePods(kc, "node-1", false, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(remaining).To(Equal(2))
+
+	evicted := false
+	for _, a := range kc.Actions() {
+		if a.GetResource().Resource == "pods" && a.GetSubresource() == "eviction" {
+			evicted = true
+		}
+	}
+	g.Expect(evicted).To(BeTrue())
+
+	// A PodDisruptionBudget-blocked eviction isn't an error; the pod is still
+	// counted as remaining so the next call retries it.
+	kc = kubernetesfake.NewSimpleClientset(podOnNode("a", "node-1"))
+	kc.PrependReactor("*", "pods", func(a k8stesting.Action) (bool, runtime.Object, error) {
+		if a.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		return true, nil, kerrors.NewTooManyRequests("blocked by PodDisruptionBudget", 0)
+	})
+	remaining, err = evictNodePods(kc, "node-1", false, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(remaining).To(Equal(1))
+
+	// Mirror and completed pods are never evicted.
+	mirror := podOnNode("mirror", "node-1")
+	mirror.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: ""}
+	completed := podOnNode("completed", "node-1")
+	completed.Status.Phase = corev1.PodSucceeded
+	kc = kubernetesfake.NewSimpleClientset(mirror, completed)
+	remaining, err = evictNodePods(kc, "node-1", false, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(remaining).To(Equal(0))
+
+	// A DaemonSet-managed pod blocks the drain unless IgnoreDaemonSets is set.
+	ds := podOnNode("ds", "node-1")
+	ds.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds"}}
+	kc = kubernetesfake.NewSimpleClientset(ds)
+	_, err = evictNodePods(kc, "node-1", false, false)
+	g.Expect(err).To(HaveOccurred())
+	remaining, err = evictNodePods(kc, "node-1", true, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(remaining).To(Equal(0))
+
+	// A pod using an emptyDir volume blocks the drain unless
+	// DeleteEmptyDirData is set.
+	ed := podOnNode("ed", "node-1")
+	ed.Spec.Volumes = []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	kc = kubernetesfake.NewSimpleClientset(ed)
+	_, err = evictNodePods(kc, "node-1", false, false)
+	g.Expect(err).To(HaveOccurred())
+	remaining, err = evictNodePods(kc, "node-1", false, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(remaining).To(Equal(1))
+}
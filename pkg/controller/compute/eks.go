@@ -0,0 +1,407 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compute implements controllers for AWS compute resources, such as
+// EKS clusters.
+package compute
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	"github.com/crossplaneio/stack-aws/pkg/clients/eks"
+)
+
+const (
+	controllerName = "eks.compute.aws.crossplane.io"
+	finalizer      = "finalizer." + controllerName
+
+	clusterNamePrefix = "eks-"
+
+	aShortWait = 30 * time.Second
+	aLongWait  = 60 * time.Second
+
+	// tokenSafetyMargin is subtracted from a connection token's observed
+	// expiry so we requeue (and mint a fresh token) before the one we
+	// published actually stops working.
+	tokenSafetyMargin = 2 * time.Minute
+)
+
+var ctx = context.Background()
+
+// Add creates a new EKSCluster Controller and adds it to the Manager with
+// default RBAC. The Manager will set fields on the Controller and start it
+// when the Manager is started.
+func Add(mgr manager.Manager) error {
+	r := newReconciler(mgr)
+
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return errors.Wrap(err, "cannot create controller")
+	}
+
+	return c.Watch(&source.Kind{Type: &EKSCluster{}}, &handler.EnqueueRequestForObject{})
+}
+
+func newReconciler(mgr manager.Manager) *Reconciler {
+	r := &Reconciler{
+		Client:    mgr.GetClient(),
+		publisher: resource.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()),
+	}
+
+	r.connect = r._connect
+	r.create = r._create
+	r.sync = r._sync
+	r.delete = r._delete
+	r.secret = r._secret
+	r.awsauth = r._awsauth
+	r.drainWorkers = r._drainWorkers
+
+	return r
+}
+
+// Reconciler reconciles EKSClusters.
+type Reconciler struct {
+	client.Client
+	publisher resource.ManagedConnectionPublisher
+
+	connect func(*EKSCluster) (eks.Client, error)
+	create  func(*EKSCluster, eks.Client) (reconcile.Result, error)
+	sync    func(*EKSCluster, eks.Client) (reconcile.Result, error)
+	delete  func(*EKSCluster, eks.Client) (reconcile.Result, error)
+	secret  func(*eks.Cluster, *EKSCluster, eks.Client) error
+	awsauth func(*eks.Cluster, *EKSCluster, eks.Client, string) error
+
+	// drainWorkers cordons and drains the downstream cluster's worker nodes.
+	// newReconciler sets it; when it is nil, as in a Reconciler built
+	// without newReconciler, _delete skips the drain step entirely.
+	drainWorkers func(*eks.Cluster, *EKSCluster, eks.Client) (bool, error)
+}
+
+// Reconcile reads and reconciles an EKSCluster.
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cluster := &EKSCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "cannot get EKSCluster")
+	}
+
+	client, err := r.connect(cluster)
+	if err != nil {
+		cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+	}
+
+	if meta.WasDeleted(cluster) {
+		return r.delete(cluster, client)
+	}
+
+	meta.AddFinalizer(cluster, finalizer)
+
+	if cluster.Status.ClusterName == "" {
+		return r.create(cluster, client)
+	}
+
+	return r.sync(cluster, client)
+}
+
+func isErrorBadRequest(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "InvalidParameterException")
+}
+
+func (r *Reconciler) _create(cluster *EKSCluster, client eks.Client) (reconcile.Result, error) {
+	cluster.Status.SetConditions(runtimev1alpha1.Creating())
+
+	clusterName := fmt.Sprintf("%s%s", clusterNamePrefix, cluster.UID)
+	if _, err := client.Create(clusterName, cluster.Spec); err != nil {
+		cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		if isErrorBadRequest(err) {
+			return reconcile.Result{}, r.Update(ctx, cluster)
+		}
+		return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+	}
+
+	meta.AddFinalizer(cluster, finalizer)
+	cluster.Status.ClusterName = clusterName
+	cluster.Status.State = ClusterStatusCreating
+	cluster.Status.Phase = PhaseCreatingControlPlane
+	cluster.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+
+	return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+}
+
+// _sync advances an EKSCluster through its reconciliation phases. Each call
+// runs a single phaseStep: phases that aren't yet ready for the next step
+// requeue in place, and an error leaves the cluster's phase unchanged so the
+// same step is retried.
+func (r *Reconciler) _sync(cluster *EKSCluster, client eks.Client) (reconcile.Result, error) {
+	eksCluster, err := client.Get(cluster.Status.ClusterName)
+	if err != nil {
+		cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+	}
+
+	phase := cluster.Status.Phase
+	if phase == "" {
+		phase = PhaseWaitingForControlPlane
+	}
+
+	step, ok := phaseSteps[phase]
+	if !ok {
+		step = phaseSteps[PhaseWaitingForControlPlane]
+	}
+
+	next, requeue, err := step(r, eksCluster, cluster, client)
+	if err != nil {
+		cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+	}
+
+	cluster.Status.Phase = next
+	cluster.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	return reconcile.Result{RequeueAfter: requeue}, r.Update(ctx, cluster)
+}
+
+// workerMode returns the cluster's configured WorkerMode, defaulting to
+// WorkerModeSelfManaged for clusters that predate the WorkerMode field.
+func workerMode(cluster *EKSCluster) WorkerMode {
+	if cluster.Spec.WorkerMode == "" {
+		return WorkerModeSelfManaged
+	}
+	return cluster.Spec.WorkerMode
+}
+
+// nodeGroupName returns the configured managed node group name, or a default
+// derived from the cluster name.
+func nodeGroupName(cluster *EKSCluster) string {
+	if cluster.Spec.NodeGroup.NodeGroupName != "" {
+		return cluster.Spec.NodeGroup.NodeGroupName
+	}
+	return cluster.Status.ClusterName + "-nodegroup"
+}
+
+// fargateProfileName returns the configured Fargate profile name, or a
+// default derived from the cluster name.
+func fargateProfileName(cluster *EKSCluster) string {
+	if cluster.Spec.FargateProfile.ProfileName != "" {
+		return cluster.Spec.FargateProfile.ProfileName
+	}
+	return cluster.Status.ClusterName + "-fargate"
+}
+
+// requeueForTokenExpiry returns the shorter of def and the time remaining
+// until the published connection token expires (less a safety margin), so
+// _sync runs again in time to refresh it. def is returned unchanged if no
+// token expiry has been recorded, or if that margin has already been reached.
+func requeueForTokenExpiry(cluster *EKSCluster, def time.Duration) time.Duration {
+	if cluster.Status.ConnectionTokenExpiresAt == nil {
+		return def
+	}
+
+	untilExpiry := time.Until(cluster.Status.ConnectionTokenExpiresAt.Time) - tokenSafetyMargin
+	if untilExpiry <= 0 || untilExpiry >= def {
+		return def
+	}
+	return untilExpiry
+}
+
+// tokenNeedsRefresh returns true once the published connection token is
+// within its safety margin of expiry, or its expiry was never recorded,
+// signalling that the connection secret must be republished with a fresh
+// token before it's honoured.
+func tokenNeedsRefresh(cluster *EKSCluster) bool {
+	if cluster.Status.ConnectionTokenExpiresAt == nil {
+		return true
+	}
+	return time.Until(cluster.Status.ConnectionTokenExpiresAt.Time) <= tokenSafetyMargin
+}
+
+func (r *Reconciler) _secret(cluster *eks.Cluster, mg *EKSCluster, client eks.Client) error {
+	token, err := client.ConnectionToken(mg.Status.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// The token's expiry is encoded in its own presigned URL. Parsing is
+	// best-effort: an unparseable token (e.g. from an old client, or a test
+	// double) just means we fall back to our default resync interval rather
+	// than refreshing ahead of expiry.
+	if exp, err := eks.TokenExpiry(token); err == nil {
+		t := metav1.NewTime(exp)
+		mg.Status.ConnectionTokenExpiresAt = &t
+	}
+
+	ca, err := base64.StdEncoding.DecodeString(cluster.CA)
+	if err != nil {
+		return errors.Wrap(err, "cannot decode cluster certificate authority")
+	}
+
+	return r.publisher.PublishConnection(ctx, mg, resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(cluster.Endpoint),
+		runtimev1alpha1.ResourceCredentialsSecretCAKey:       ca,
+		runtimev1alpha1.ResourceCredentialsTokenKey:          []byte(token),
+	})
+}
+
+func (r *Reconciler) _awsauth(cluster *eks.Cluster, mg *EKSCluster, client eks.Client, workerARN string) error {
+	cm, checksum, err := generateAWSAuthConfigMap(mg, workerARN)
+	if err != nil {
+		return errors.Wrap(err, "cannot generate aws-auth ConfigMap")
+	}
+
+	if checksum == mg.Status.AWSAuthConfigMapChecksum {
+		// MapRoles/MapUsers haven't changed since we last applied the
+		// ConfigMap; avoid an unnecessary round-trip to the downstream
+		// cluster on every reconcile.
+		return nil
+	}
+
+	kc, err := kubernetesClientForCluster(cluster, mg, client)
+	if err != nil {
+		return errors.Wrap(err, "cannot create downstream cluster client")
+	}
+
+	if _, err := kc.CoreV1().ConfigMaps(cm.Namespace).Create(cm); err != nil {
+		if !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, "cannot create aws-auth ConfigMap")
+		}
+		if _, err := kc.CoreV1().ConfigMaps(cm.Namespace).Update(cm); err != nil {
+			return errors.Wrap(err, "cannot update aws-auth ConfigMap")
+		}
+	}
+
+	mg.Status.AWSAuthConfigMapChecksum = checksum
+	return nil
+}
+
+func (r *Reconciler) _delete(cluster *EKSCluster, client eks.Client) (reconcile.Result, error) {
+	cluster.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	if cluster.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
+		if r.drainWorkers != nil && cluster.Status.CloudFormationStackID != "" &&
+			cluster.GetCondition(ConditionDrainingSucceeded).Status != corev1.ConditionTrue {
+			eksCluster, err := client.Get(cluster.Status.ClusterName)
+			if err != nil {
+				cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(err, "cannot get cluster to drain worker nodes")))
+				return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+			}
+
+			drained, err := r.drainWorkers(eksCluster, cluster, client)
+			if err != nil {
+				cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(err, "cannot drain worker nodes")))
+				return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+			}
+			if !drained {
+				return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+			}
+
+			cluster.Status.SetConditions(drainingSucceeded())
+			return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+		}
+
+		var masterErr, workerErr error
+		switch {
+		case cluster.Status.CloudFormationStackID != "":
+			workerErr = client.DeleteWorkerNodes(cluster.Status.CloudFormationStackID)
+		case cluster.Status.NodeGroupARN != "":
+			workerErr = client.DeleteNodeGroup(cluster.Status.ClusterName, nodeGroupName(cluster))
+		case cluster.Status.FargateProfileARN != "":
+			workerErr = client.DeleteFargateProfile(cluster.Status.ClusterName, fargateProfileName(cluster))
+		}
+		masterErr = client.Delete(cluster.Status.ClusterName)
+
+		switch {
+		case masterErr != nil && workerErr != nil:
+			err := fmt.Errorf("Master Delete Error: %s, Worker Delete Error: %s", masterErr, workerErr)
+			cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+		case masterErr != nil:
+			cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(masterErr, "Master Delete Error")))
+			return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+		case workerErr != nil:
+			cluster.Status.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(workerErr, "Worker Delete Error")))
+			return reconcile.Result{RequeueAfter: aShortWait}, r.Update(ctx, cluster)
+		}
+	}
+
+	meta.RemoveFinalizer(cluster, finalizer)
+	cluster.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	return reconcile.Result{}, r.Update(ctx, cluster)
+}
+
+// generateAWSAuthConfigMap generates the aws-auth ConfigMap that maps IAM
+// roles and users onto Kubernetes users and groups, including the default
+// mapping required for the cluster's own worker nodes to join. It also
+// returns a checksum of the rendered ConfigMap data, which callers can
+// compare against EKSClusterStatus.AWSAuthConfigMapChecksum to detect
+// whether re-applying it is actually necessary.
+func generateAWSAuthConfigMap(cluster *EKSCluster, workerARN string) (*corev1.ConfigMap, string, error) {
+	defaultRole := MapRole{
+		RoleARN:  workerARN,
+		Username: "system:node:{{EC2PrivateDNSName}}",
+		Groups:   []string{"system:bootstrappers", "system:nodes"},
+	}
+
+	roles := append(append([]MapRole{}, cluster.Spec.MapRoles...), defaultRole)
+
+	roleData, err := yaml.Marshal(roles)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "cannot marshal mapRoles")
+	}
+
+	userData, err := yaml.Marshal(cluster.Spec.MapUsers)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "cannot marshal mapUsers")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-auth",
+			Namespace: "kube-system",
+		},
+		Data: map[string]string{
+			"mapRoles": string(roleData),
+			"mapUsers": string(userData),
+		},
+	}
+
+	sum := sha256.Sum256(append(roleData, userData...))
+	return cm, hex.EncodeToString(sum[:]), nil
+}
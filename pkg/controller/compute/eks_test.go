@@ -21,8 +21,10 @@ import (
 	"encoding/base64"
 	"fmt"
 	"testing"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/ghodss/yaml"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
@@ -123,8 +125,9 @@ func TestGenerateEksAuth(t *testing.T) {
 	// Default is included by so we don't add it to spec
 	expectRoles = append(expectRoles, defaultMapRole)
 
-	cm, err := generateAWSAuthConfigMap(cluster, arnName)
+	cm, checksum, err := generateAWSAuthConfigMap(cluster, arnName)
 	g.Expect(err).To(BeNil())
+	g.Expect(checksum).NotTo(BeEmpty())
 
 	g.Expect(cm.Name).To(Equal("aws-auth"))
 	g.Expect(cm.Namespace).To(Equal("kube-system"))
@@ -141,6 +144,33 @@ func TestGenerateEksAuth(t *testing.T) {
 
 	g.Expect(outputRoles).To(Equal(expectRoles))
 	g.Expect(outputUsers).To(Equal(expectUsers))
+
+	// the checksum is stable for identical input and changes when the
+	// rendered ConfigMap would change.
+	_, checksumAgain, err := generateAWSAuthConfigMap(cluster, arnName)
+	g.Expect(err).To(BeNil())
+	g.Expect(checksumAgain).To(Equal(checksum))
+
+	_, checksumChanged, err := generateAWSAuthConfigMap(cluster, "a-different-worker-arn")
+	g.Expect(err).To(BeNil())
+	g.Expect(checksumChanged).NotTo(Equal(checksum))
+}
+
+func TestAWSAuthSkipsUnchangedConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	mg := testCluster()
+	_, checksum, err := generateAWSAuthConfigMap(mg, "worker-arn")
+	g.Expect(err).To(BeNil())
+	mg.Status.AWSAuthConfigMapChecksum = checksum
+
+	r := &Reconciler{}
+
+	// No connection details are configured on the fake client, so this
+	// would error loudly if _awsauth attempted to reach the downstream
+	// cluster instead of short-circuiting on the matching checksum.
+	err = r._awsauth(&eks.Cluster{}, mg, &fake.MockEKSClient{}, "worker-arn")
+	g.Expect(err).To(BeNil())
 }
 
 func TestCreate(t *testing.T) {
@@ -206,12 +236,17 @@ func TestCreate(t *testing.T) {
 	g.Expect(reconciledCluster.Status.CloudFormationStackID).To(BeEmpty())
 }
 
+// TestSync drives _sync one phase transition at a time, the way the
+// reconciler itself is invoked. Each case sets up the phase a cluster starts
+// in and asserts the single step _sync takes from there: the phase it lands
+// on, the requeue interval, and the conditions it records.
 func TestSync(t *testing.T) {
 	g := NewGomegaWithT(t)
 	fakeStackID := "fake-stack-id"
+	fakeWorkerARN := "fake-worker-arn"
 
-	test := func(tc *EKSCluster, cl *fake.MockEKSClient, sec func(*eks.Cluster, *EKSCluster, eks.Client) error, auth func(*eks.Cluster, *EKSCluster, eks.Client, string) error,
-		rslt reconcile.Result, exp runtimev1alpha1.ConditionedStatus) *EKSCluster {
+	test := func(tc *EKSCluster, cl eks.Client, sec func(*eks.Cluster, *EKSCluster, eks.Client) error, auth func(*eks.Cluster, *EKSCluster, eks.Client, string) error,
+		rslt reconcile.Result, exp runtimev1alpha1.ConditionedStatus, wantPhase ClusterPhase) *EKSCluster {
 		r := &Reconciler{
 			Client:  NewFakeClient(tc),
 			secret:  sec,
@@ -221,135 +256,281 @@ func TestSync(t *testing.T) {
 		rs, err := r._sync(tc, cl)
 		g.Expect(rs).To(Equal(rslt))
 		g.Expect(err).NotTo(HaveOccurred())
-		return assertResource(g, r, exp)
-	}
-
-	fakeWorkerARN := "fake-worker-arn"
-	mockClusterWorker := eks.ClusterWorkers{
-		WorkerStackID: fakeStackID,
-		WorkerARN:     fakeWorkerARN,
+		reconciled := assertResource(g, r, exp)
+		g.Expect(reconciled.Status.Phase).To(Equal(wantPhase))
+		return reconciled
 	}
 
-	// error retrieving the cluster
+	// error retrieving the cluster leaves the phase untouched.
 	errorGet := errors.New("retrieving cluster")
 	cl := &fake.MockEKSClient{
-		MockGet: func(string) (*eks.Cluster, error) {
-			return nil, errorGet
-		},
-		MockCreateWorkerNodes: func(string, string, EKSClusterSpec) (*eks.ClusterWorkers, error) { return &mockClusterWorker, nil },
-	}
-
-	cl.MockGetWorkerNodes = func(string) (*eks.ClusterWorkers, error) {
-		return &eks.ClusterWorkers{
-			WorkersStatus: cloudformation.StackStatusCreateInProgress,
-			WorkerReason:  "",
-			WorkerStackID: fakeStackID}, nil
+		MockGet: func(string) (*eks.Cluster, error) { return nil, errorGet },
 	}
-
 	expectedStatus := runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errorGet))
 	tc := testCluster()
-	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
+	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, "")
 
-	// cluster is not ready
+	// control plane still creating: stay in PhaseWaitingForControlPlane.
 	cl.MockGet = func(string) (*eks.Cluster, error) {
-		return &eks.Cluster{
-			Status: ClusterStatusCreating,
-		}, nil
+		return &eks.Cluster{Status: ClusterStatusCreating}, nil
 	}
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	tc = testCluster()
-	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
+	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseWaitingForControlPlane)
 
-	// cluster is ready, but lets create workers that error
+	// control plane active and workers not yet started: move on to
+	// PhaseCreatingWorkers.
 	cl.MockGet = func(string) (*eks.Cluster, error) {
-		return &eks.Cluster{
-			Status: ClusterStatusActive,
-		}, nil
+		return &eks.Cluster{Status: ClusterStatusActive}, nil
 	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(controlPlaneReady(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	reconciledCluster := test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseCreatingWorkers)
+	g.Expect(reconciledCluster.Status.CloudFormationStackID).To(BeEmpty())
 
+	// PhaseCreatingWorkers: an error creating the worker stack leaves the
+	// phase unchanged so it's retried.
 	errorCreateNodes := errors.New("create nodes")
 	cl.MockCreateWorkerNodes = func(string, string, EKSClusterSpec) (*eks.ClusterWorkers, error) {
 		return nil, errorCreateNodes
 	}
-
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errorCreateNodes))
 	tc = testCluster()
-	reconciledCluster := test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
+	tc.Status.Phase = PhaseCreatingWorkers
+	reconciledCluster = test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseCreatingWorkers)
 	g.Expect(reconciledCluster.Status.CloudFormationStackID).To(BeEmpty())
 
-	// cluster is ready, lets create workers
-	cl.MockGet = func(string) (*eks.Cluster, error) {
-		return &eks.Cluster{
-			Status: ClusterStatusActive,
-		}, nil
-	}
-
+	// PhaseCreatingWorkers: the worker stack is created and we move on to
+	// PhaseWaitingForWorkers.
 	cl.MockCreateWorkerNodes = func(string, string, EKSClusterSpec) (*eks.ClusterWorkers, error) {
 		return &eks.ClusterWorkers{WorkerStackID: fakeStackID}, nil
 	}
-
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	tc = testCluster()
-	reconciledCluster = test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
+	tc.Status.Phase = PhaseCreatingWorkers
+	reconciledCluster = test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseWaitingForWorkers)
 	g.Expect(reconciledCluster.Status.CloudFormationStackID).To(Equal(fakeStackID))
 
-	// cluster is ready, but auth sync failed
+	// PhaseWaitingForWorkers: the worker stack is still creating, so we stay
+	// put.
 	cl.MockGetWorkerNodes = func(string) (*eks.ClusterWorkers, error) {
 		return &eks.ClusterWorkers{
-			WorkersStatus: cloudformation.StackStatusCreateComplete,
-			WorkerReason:  "",
+			WorkersStatus: cftypes.StackStatusCreateInProgress,
 			WorkerStackID: fakeStackID,
-			WorkerARN:     fakeWorkerARN,
 		}, nil
 	}
-
-	errorAuth := errors.New("auth")
-	expectedStatus = runtimev1alpha1.ConditionedStatus{}
-	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(errorAuth, "failed to set auth map on eks")))
 	tc = testCluster()
+	tc.Status.Phase = PhaseWaitingForWorkers
 	tc.Status.CloudFormationStackID = fakeStackID
-	auth := func(*eks.Cluster, *EKSCluster, eks.Client, string) error {
-		return errorAuth
+	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseWaitingForWorkers)
 
-	}
-	test(tc, cl, nil, auth, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
-
-	// cluster is ready, but secret failed
+	// PhaseWaitingForWorkers: the worker stack is ready, so its IAM role is
+	// recorded and we move on to PhaseApplyingAuth.
 	cl.MockGetWorkerNodes = func(string) (*eks.ClusterWorkers, error) {
 		return &eks.ClusterWorkers{
-			WorkersStatus: cloudformation.StackStatusCreateComplete,
-			WorkerReason:  "",
+			WorkersStatus: cftypes.StackStatusCreateComplete,
 			WorkerStackID: fakeStackID,
 			WorkerARN:     fakeWorkerARN,
 		}, nil
 	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(workersReady(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Status.Phase = PhaseWaitingForWorkers
+	tc.Status.CloudFormationStackID = fakeStackID
+	reconciledCluster = test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseApplyingAuth)
+	g.Expect(reconciledCluster.Status.WorkerRoleARN).To(Equal(fakeWorkerARN))
 
-	auth = func(*eks.Cluster, *EKSCluster, eks.Client, string) error {
-		return nil
-	}
+	// PhaseApplyingAuth: an auth sync failure leaves the phase unchanged.
+	errorAuth := errors.New("auth")
+	auth := func(*eks.Cluster, *EKSCluster, eks.Client, string) error { return errorAuth }
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(errorAuth, "failed to set auth map on eks")))
+	tc = testCluster()
+	tc.Status.Phase = PhaseApplyingAuth
+	tc.Status.CloudFormationStackID = fakeStackID
+	tc.Status.WorkerRoleARN = fakeWorkerARN
+	test(tc, cl, nil, auth, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhaseApplyingAuth)
+
+	// PhaseApplyingAuth: auth succeeds, so we move on to
+	// PhasePublishingSecret.
+	auth = func(*eks.Cluster, *EKSCluster, eks.Client, string) error { return nil }
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(authApplied(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Status.Phase = PhaseApplyingAuth
+	tc.Status.CloudFormationStackID = fakeStackID
+	tc.Status.WorkerRoleARN = fakeWorkerARN
+	test(tc, cl, nil, auth, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhasePublishingSecret)
 
+	// PhasePublishingSecret: a failure to publish the connection secret
+	// leaves the phase unchanged.
 	errorSecret := errors.New("secret")
-	fSec := func(*eks.Cluster, *EKSCluster, eks.Client) error {
-		return errorSecret
-	}
+	fSec := func(*eks.Cluster, *EKSCluster, eks.Client) error { return errorSecret }
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errorSecret))
 	tc = testCluster()
+	tc.Status.Phase = PhasePublishingSecret
 	tc.Status.CloudFormationStackID = fakeStackID
-	test(tc, cl, fSec, auth, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus)
+	test(tc, cl, fSec, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhasePublishingSecret)
 
-	// cluster is ready
-	fSec = func(*eks.Cluster, *EKSCluster, eks.Client) error {
+	// PhasePublishingSecret: publishing succeeds, so we move on to
+	// PhaseReady.
+	fSec = func(*eks.Cluster, *EKSCluster, eks.Client) error { return nil }
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Status.Phase = PhasePublishingSecret
+	tc.Status.CloudFormationStackID = fakeStackID
+	test(tc, cl, fSec, nil, reconcile.Result{RequeueAfter: aLongWait}, expectedStatus, PhaseReady)
+
+	// PhaseReady: the cluster is marked Available, but its connection token's
+	// expiry was never recorded, so we route back through
+	// PhasePublishingSecret to mint and publish a fresh one.
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Status.Phase = PhaseReady
+	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: aShortWait}, expectedStatus, PhasePublishingSecret)
+
+	// PhaseReady: the connection token isn't due for renewal yet, so we stay
+	// in PhaseReady without republishing it, requeuing ahead of its expiry.
+	farFuture := metav1.NewTime(time.Now().Add(time.Hour))
+	tc = testCluster()
+	tc.Status.Phase = PhaseReady
+	tc.Status.ConnectionTokenExpiresAt = &farFuture
+	test(tc, cl, nil, nil, reconcile.Result{RequeueAfter: requeueForTokenExpiry(tc, aLongWait)}, expectedStatus, PhaseReady)
+}
+
+// TestSyncManagedNodeGroupAndFargate exercises the same phase steps as
+// TestSync for the WorkerModeManagedNodeGroup and WorkerModeFargate worker
+// modes.
+func TestSyncManagedNodeGroupAndFargate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fakeNodeGroupARN := "fake-nodegroup-arn"
+	fakeNodeRoleARN := "fake-node-role-arn"
+
+	activeCluster := func(string) (*eks.Cluster, error) {
+		return &eks.Cluster{Status: ClusterStatusActive}, nil
+	}
+
+	// managed node group: control plane active and no node group started
+	// yet, so we move on to PhaseCreatingWorkers.
+	cl := &fake.MockEKSClient{MockGet: activeCluster}
+	tc := testCluster()
+	tc.Spec.WorkerMode = WorkerModeManagedNodeGroup
+	r := &Reconciler{Client: NewFakeClient(tc)}
+	rs, err := r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(controlPlaneReady(), runtimev1alpha1.ReconcileSuccess())
+	reconciled := assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhaseCreatingWorkers))
+
+	// PhaseCreatingWorkers: the node group is created and we move on to
+	// PhaseWaitingForWorkers.
+	ngCreated := false
+	cl.MockCreateNodeGroup = func(string, NodeGroupSpec) (*eks.NodeGroup, error) {
+		ngCreated = true
+		return &eks.NodeGroup{NodeGroupARN: fakeNodeGroupARN}, nil
+	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Spec.WorkerMode = WorkerModeManagedNodeGroup
+	tc.Status.Phase = PhaseCreatingWorkers
+	r = &Reconciler{Client: NewFakeClient(tc)}
+	rs, err = r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	g.Expect(ngCreated).To(BeTrue())
+	reconciled = assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhaseWaitingForWorkers))
+	g.Expect(reconciled.Status.NodeGroupARN).To(Equal(fakeNodeGroupARN))
+
+	// PhaseWaitingForWorkers: the node group is still creating, so we stay
+	// put.
+	cl.MockGetNodeGroup = func(string, string) (*eks.NodeGroup, error) {
+		return &eks.NodeGroup{NodeGroupARN: fakeNodeGroupARN, Status: ekstypes.NodegroupStatusCreating}, nil
+	}
+	tc = testCluster()
+	tc.Spec.WorkerMode = WorkerModeManagedNodeGroup
+	tc.Status.Phase = PhaseWaitingForWorkers
+	tc.Status.NodeGroupARN = fakeNodeGroupARN
+	r = &Reconciler{Client: NewFakeClient(tc)}
+	rs, err = r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	reconciled = assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhaseWaitingForWorkers))
+
+	// PhaseWaitingForWorkers: the node group is active, so its IAM role is
+	// recorded and we move on to PhaseApplyingAuth.
+	cl.MockGetNodeGroup = func(string, string) (*eks.NodeGroup, error) {
+		return &eks.NodeGroup{NodeGroupARN: fakeNodeGroupARN, Status: ekstypes.NodegroupStatusActive}, nil
+	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(workersReady(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Spec.WorkerMode = WorkerModeManagedNodeGroup
+	tc.Spec.NodeGroup.NodeRole = fakeNodeRoleARN
+	tc.Status.Phase = PhaseWaitingForWorkers
+	tc.Status.NodeGroupARN = fakeNodeGroupARN
+	r = &Reconciler{Client: NewFakeClient(tc)}
+	rs, err = r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	reconciled = assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhaseApplyingAuth))
+	g.Expect(reconciled.Status.WorkerRoleARN).To(Equal(fakeNodeRoleARN))
+
+	// PhaseApplyingAuth: the node group's IAM role is mapped into aws-auth
+	// and we move on to PhasePublishingSecret.
+	var authedARN string
+	auth := func(_ *eks.Cluster, _ *EKSCluster, _ eks.Client, arn string) error {
+		authedARN = arn
 		return nil
 	}
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
-	expectedStatus.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+	expectedStatus.SetConditions(authApplied(), runtimev1alpha1.ReconcileSuccess())
 	tc = testCluster()
-	tc.Status.CloudFormationStackID = fakeStackID
-	test(tc, cl, fSec, auth, reconcile.Result{RequeueAfter: aLongWait}, expectedStatus)
+	tc.Spec.WorkerMode = WorkerModeManagedNodeGroup
+	tc.Status.Phase = PhaseApplyingAuth
+	tc.Status.NodeGroupARN = fakeNodeGroupARN
+	tc.Status.WorkerRoleARN = fakeNodeRoleARN
+	r = &Reconciler{Client: NewFakeClient(tc), awsauth: auth}
+	rs, err = r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	reconciled = assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhasePublishingSecret))
+	g.Expect(authedARN).To(Equal(fakeNodeRoleARN))
+
+	// fargate: PhaseWaitingForWorkers finds the profile active and moves
+	// straight to PhasePublishingSecret, skipping PhaseApplyingAuth entirely
+	// since Fargate profiles have no EC2 worker role to map into aws-auth.
+	cl.MockGetFargateProfile = func(string, string) (*eks.FargateProfile, error) {
+		return &eks.FargateProfile{ProfileARN: "fake-fargate-arn", Status: ekstypes.FargateProfileStatusActive}, nil
+	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(workersReady(), runtimev1alpha1.ReconcileSuccess())
+	tc = testCluster()
+	tc.Spec.WorkerMode = WorkerModeFargate
+	tc.Status.Phase = PhaseWaitingForWorkers
+	tc.Status.FargateProfileARN = "fake-fargate-arn"
+	r = &Reconciler{Client: NewFakeClient(tc)}
+	rs, err = r._sync(tc, cl)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	reconciled = assertResource(g, r, expectedStatus)
+	g.Expect(reconciled.Status.Phase).To(Equal(PhasePublishingSecret))
+	g.Expect(reconciled.Status.WorkerRoleARN).To(BeEmpty())
 }
 
 func TestSecret(t *testing.T) {
@@ -485,6 +666,52 @@ func TestDelete(t *testing.T) {
 	g.Expect(reconciledCluster.Finalizers).To(ContainElement(finalizer))
 }
 
+func TestDeleteDrainsWorkersBeforeStackTeardown(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cluster := testCluster()
+	cluster.Finalizers = []string{finalizer}
+	cluster.Spec.ReclaimPolicy = runtimev1alpha1.ReclaimDelete
+	cluster.Status.CloudFormationStackID = "fake-stack-id"
+	cluster.Status.SetConditions(runtimev1alpha1.Available())
+
+	client := &fake.MockEKSClient{
+		MockGet:               func(string) (*eks.Cluster, error) { return &eks.Cluster{}, nil },
+		MockDelete:            func(string) error { return nil },
+		MockDeleteWorkerNodes: func(string) error { return nil },
+	}
+
+	// draining still in progress: requeue, don't touch the CloudFormation
+	// stacks, and don't mark draining as succeeded.
+	r := &Reconciler{
+		Client:       NewFakeClient(cluster),
+		drainWorkers: func(*eks.Cluster, *EKSCluster, eks.Client) (bool, error) { return false, nil },
+	}
+
+	rs, err := r._delete(cluster, client)
+	g.Expect(err).To(BeNil())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	g.Expect(cluster.GetCondition(ConditionDrainingSucceeded).Status).NotTo(Equal(corev1.ConditionTrue))
+	g.Expect(cluster.Finalizers).To(ContainElement(finalizer))
+
+	// draining succeeds: the DrainingSucceeded condition is recorded and we
+	// requeue before actually tearing anything down.
+	r.drainWorkers = func(*eks.Cluster, *EKSCluster, eks.Client) (bool, error) { return true, nil }
+
+	rs, err = r._delete(cluster, client)
+	g.Expect(err).To(BeNil())
+	g.Expect(rs).To(Equal(reconcile.Result{RequeueAfter: aShortWait}))
+	g.Expect(cluster.GetCondition(ConditionDrainingSucceeded).Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cluster.Finalizers).To(ContainElement(finalizer))
+
+	// now that draining has succeeded, the next delete tears down the
+	// CloudFormation stacks and removes the finalizer as usual.
+	rs, err = r._delete(cluster, client)
+	g.Expect(err).To(BeNil())
+	g.Expect(rs).To(Equal(reconcile.Result{}))
+	g.Expect(cluster.Finalizers).To(BeEmpty())
+}
+
 func TestReconcileObjectNotFound(t *testing.T) {
 	g := NewGomegaWithT(t)
 
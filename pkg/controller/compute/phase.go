@@ -0,0 +1,217 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"time"
+
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+	"github.com/crossplaneio/stack-aws/pkg/clients/eks"
+)
+
+// Condition types set as an EKSCluster moves through its reconciliation
+// phases.
+const (
+	ConditionControlPlaneReady runtimev1alpha1.ConditionType = "ControlPlaneReady"
+	ConditionWorkersReady      runtimev1alpha1.ConditionType = "WorkersReady"
+	ConditionAuthApplied       runtimev1alpha1.ConditionType = "AuthApplied"
+)
+
+func controlPlaneReady() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               ConditionControlPlaneReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Control plane is active",
+	}
+}
+
+func workersReady() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               ConditionWorkersReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Worker capacity is ready",
+	}
+}
+
+func authApplied() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               ConditionAuthApplied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "aws-auth ConfigMap applied",
+	}
+}
+
+// phaseStep advances an EKSCluster through a single reconciliation phase,
+// returning the phase to transition to and how long to wait before the next
+// reconcile. An error leaves the cluster's phase unchanged so the step is
+// retried.
+type phaseStep func(r *Reconciler, eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error)
+
+var phaseSteps = map[ClusterPhase]phaseStep{
+	PhaseCreatingControlPlane:   (*Reconciler).stepWaitingForControlPlane,
+	PhaseWaitingForControlPlane: (*Reconciler).stepWaitingForControlPlane,
+	PhaseCreatingWorkers:        (*Reconciler).stepCreatingWorkers,
+	PhaseWaitingForWorkers:      (*Reconciler).stepWaitingForWorkers,
+	PhaseApplyingAuth:           (*Reconciler).stepApplyingAuth,
+	PhasePublishingSecret:       (*Reconciler).stepPublishingSecret,
+	PhaseReady:                  (*Reconciler).stepReady,
+}
+
+// workerResourceStarted returns true once the worker resource for the
+// cluster's WorkerMode has been created, even if it's not yet ready for use.
+func workerResourceStarted(cluster *EKSCluster) bool {
+	switch workerMode(cluster) {
+	case WorkerModeManagedNodeGroup:
+		return cluster.Status.NodeGroupARN != ""
+	case WorkerModeFargate:
+		return cluster.Status.FargateProfileARN != ""
+	default:
+		return cluster.Status.CloudFormationStackID != ""
+	}
+}
+
+func (r *Reconciler) stepWaitingForControlPlane(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	if eksCluster.Status != ClusterStatusActive {
+		return PhaseWaitingForControlPlane, aShortWait, nil
+	}
+
+	cluster.Status.SetConditions(controlPlaneReady())
+
+	if workerResourceStarted(cluster) {
+		return PhaseWaitingForWorkers, aShortWait, nil
+	}
+	return PhaseCreatingWorkers, aShortWait, nil
+}
+
+func (r *Reconciler) stepCreatingWorkers(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	switch workerMode(cluster) {
+	case WorkerModeManagedNodeGroup:
+		ng, err := client.CreateNodeGroup(cluster.Status.ClusterName, cluster.Spec.NodeGroup)
+		if err != nil {
+			return PhaseCreatingWorkers, 0, err
+		}
+		cluster.Status.NodeGroupARN = ng.NodeGroupARN
+	case WorkerModeFargate:
+		fp, err := client.CreateFargateProfile(cluster.Status.ClusterName, cluster.Spec.FargateProfile)
+		if err != nil {
+			return PhaseCreatingWorkers, 0, err
+		}
+		cluster.Status.FargateProfileARN = fp.ProfileARN
+	default:
+		workers, err := client.CreateWorkerNodes(cluster.Status.ClusterName, eksCluster.ClusterVersion, cluster.Spec)
+		if err != nil {
+			return PhaseCreatingWorkers, 0, err
+		}
+		cluster.Status.CloudFormationStackID = workers.WorkerStackID
+	}
+
+	return PhaseWaitingForWorkers, aShortWait, nil
+}
+
+// pollWorkers returns the IAM role ARN that should be mapped into the
+// aws-auth ConfigMap (empty if the mode has no such role, e.g. Fargate) and
+// whether the worker resource for the cluster's WorkerMode is ready for use.
+func pollWorkers(cluster *EKSCluster, client eks.Client) (workerARN string, ready bool, err error) {
+	switch workerMode(cluster) {
+	case WorkerModeManagedNodeGroup:
+		ng, err := client.GetNodeGroup(cluster.Status.ClusterName, nodeGroupName(cluster))
+		if err != nil {
+			return "", false, err
+		}
+		if ng.Status != ekstypes.NodegroupStatusActive {
+			return "", false, nil
+		}
+		return cluster.Spec.NodeGroup.NodeRole, true, nil
+	case WorkerModeFargate:
+		fp, err := client.GetFargateProfile(cluster.Status.ClusterName, fargateProfileName(cluster))
+		if err != nil {
+			return "", false, err
+		}
+		if fp.Status != ekstypes.FargateProfileStatusActive {
+			return "", false, nil
+		}
+		return "", true, nil
+	default:
+		workers, err := client.GetWorkerNodes(cluster.Status.CloudFormationStackID)
+		if err != nil {
+			return "", false, err
+		}
+		if workers.WorkersStatus != cftypes.StackStatusCreateComplete {
+			return "", false, nil
+		}
+		return workers.WorkerARN, true, nil
+	}
+}
+
+func (r *Reconciler) stepWaitingForWorkers(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	workerARN, ready, err := pollWorkers(cluster, client)
+	if err != nil {
+		return PhaseWaitingForWorkers, 0, err
+	}
+	if !ready {
+		return PhaseWaitingForWorkers, aShortWait, nil
+	}
+
+	cluster.Status.SetConditions(workersReady())
+	cluster.Status.WorkerRoleARN = workerARN
+
+	// Fargate profiles have no EC2 worker role to map into aws-auth.
+	if workerARN == "" {
+		return PhasePublishingSecret, aShortWait, nil
+	}
+	return PhaseApplyingAuth, aShortWait, nil
+}
+
+func (r *Reconciler) stepApplyingAuth(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	if err := r.awsauth(eksCluster, cluster, client, cluster.Status.WorkerRoleARN); err != nil {
+		return PhaseApplyingAuth, 0, errors.Wrap(err, "failed to set auth map on eks")
+	}
+
+	cluster.Status.SetConditions(authApplied())
+	return PhasePublishingSecret, aShortWait, nil
+}
+
+func (r *Reconciler) stepPublishingSecret(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	if err := r.secret(eksCluster, cluster, client); err != nil {
+		return PhasePublishingSecret, 0, err
+	}
+
+	return PhaseReady, requeueForTokenExpiry(cluster, aLongWait), nil
+}
+
+func (r *Reconciler) stepReady(eksCluster *eks.Cluster, cluster *EKSCluster, client eks.Client) (ClusterPhase, time.Duration, error) {
+	cluster.Status.SetConditions(runtimev1alpha1.Available())
+
+	// Route back through PhasePublishingSecret to mint and publish a fresh
+	// connection token before the one we last published expires.
+	if tokenNeedsRefresh(cluster) {
+		return PhasePublishingSecret, aShortWait, nil
+	}
+
+	return PhaseReady, requeueForTokenExpiry(cluster, aLongWait), nil
+}
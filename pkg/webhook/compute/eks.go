@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compute implements validating webhooks for AWS compute resources,
+// such as EKS clusters.
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/pkg/errors"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+)
+
+const webhookPath = "/validate-compute-aws-crossplane-io-v1alpha2-ekscluster"
+
+// webhookConfigurationName is the name of the ValidatingWebhookConfiguration
+// that routes EKSCluster admission requests to this webhook.
+const webhookConfigurationName = "validating-webhook.compute.aws.crossplane.io"
+
+// arnPattern matches a well-formed IAM role or user ARN, e.g.
+// arn:aws:iam::123456789012:role/KubernetesAdmin.
+var arnPattern = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:iam::\d{12}:(role|user)/[\w+=,.@-]+$`)
+
+// eksRegions are the AWS regions EKS is available in at the time of writing.
+var eksRegions = map[string]bool{
+	"us-east-1":      true,
+	"us-east-2":      true,
+	"us-west-2":      true,
+	"ca-central-1":   true,
+	"eu-west-1":      true,
+	"eu-west-2":      true,
+	"eu-west-3":      true,
+	"eu-central-1":   true,
+	"eu-north-1":     true,
+	"ap-northeast-1": true,
+	"ap-northeast-2": true,
+	"ap-southeast-1": true,
+	"ap-southeast-2": true,
+	"ap-south-1":     true,
+	"sa-east-1":      true,
+}
+
+// Add registers the EKSCluster validating webhook with the supplied Manager's
+// webhook server, and ensures a ValidatingWebhookConfiguration exists that
+// routes EKSCluster admission requests to it.
+func Add(mgr manager.Manager, caBundle []byte, serviceNamespace, serviceName string) error {
+	mgr.GetWebhookServer().Register(webhookPath, &webhook.Admission{Handler: &Validator{}})
+
+	cfg := ValidatingWebhookConfiguration(caBundle, serviceNamespace, serviceName)
+	if err := mgr.GetClient().Create(context.Background(), cfg); err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "cannot create EKSCluster ValidatingWebhookConfiguration")
+	}
+	return nil
+}
+
+// ValidatingWebhookConfiguration returns the ValidatingWebhookConfiguration
+// that routes EKSCluster create, update and delete requests to the webhook
+// server's Service at webhookPath.
+func ValidatingWebhookConfiguration(caBundle []byte, serviceNamespace, serviceName string) *admissionregistrationv1beta1.ValidatingWebhookConfiguration {
+	path := webhookPath
+	fail := admissionregistrationv1beta1.Fail
+	none := admissionregistrationv1beta1.SideEffectClassNone
+
+	return &admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: webhookConfigurationName},
+		Webhooks: []admissionregistrationv1beta1.Webhook{{
+			Name: webhookConfigurationName,
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: serviceNamespace,
+					Name:      serviceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+					admissionregistrationv1beta1.Update,
+					admissionregistrationv1beta1.Delete,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{Group},
+					APIVersions: []string{Version},
+					Resources:   []string{"eksclusters"},
+				},
+			}},
+			FailurePolicy: &fail,
+			SideEffects:   &none,
+		}},
+	}
+}
+
+// Validator validates writes to EKSClusters. It denies deletion of clusters
+// that are still bound to a resource claim, and denies creates and updates
+// that specify malformed IAM ARNs, an unsupported region, or inconsistent
+// worker node sizing.
+type Validator struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder the controller-runtime webhook server
+// uses to convert admission requests into typed objects.
+func (v *Validator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle validates an admission request for an EKSCluster.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1beta1.Delete:
+		return v.handleDelete(req)
+	case admissionv1beta1.Create, admissionv1beta1.Update:
+		return v.handleWrite(req)
+	default:
+		return admission.Allowed("")
+	}
+}
+
+func (v *Validator) handleDelete(req admission.Request) admission.Response {
+	cluster := &EKSCluster{}
+	if err := v.decoder.DecodeRaw(req.OldObject, cluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if ref := cluster.Spec.ClaimReference; ref != nil {
+		return admission.Denied(fmt.Sprintf("cannot delete an EKSCluster claimed by %s %s/%s", ref.Kind, ref.Namespace, ref.Name))
+	}
+	return admission.Allowed("")
+}
+
+func (v *Validator) handleWrite(req admission.Request) admission.Response {
+	cluster := &EKSCluster{}
+	if err := v.decoder.Decode(req, cluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := validate(cluster); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validate checks that an EKSCluster's MapRoles/MapUsers ARNs are
+// well-formed, that its region is a real EKS region, and that its
+// WorkerNodes sizing is internally consistent.
+func validate(cluster *EKSCluster) error {
+	for _, r := range cluster.Spec.MapRoles {
+		if !arnPattern.MatchString(r.RoleARN) {
+			return errors.Errorf("spec.mapRoles: %q is not a well-formed IAM role ARN", r.RoleARN)
+		}
+	}
+	for _, u := range cluster.Spec.MapUsers {
+		if !arnPattern.MatchString(u.UserARN) {
+			return errors.Errorf("spec.mapUsers: %q is not a well-formed IAM user ARN", u.UserARN)
+		}
+	}
+
+	if !eksRegions[cluster.Spec.Region] {
+		return errors.Errorf("spec.region: %q is not a region EKS is available in", cluster.Spec.Region)
+	}
+
+	return validateWorkerNodeSizing(cluster.Spec.WorkerNodes)
+}
+
+// validateWorkerNodeSizing checks that min size <= desired capacity <= max
+// size, skipping any comparison whose operands aren't both set.
+func validateWorkerNodeSizing(w WorkerNodesSpec) error {
+	min, desired, max := w.NodeAutoScalingGroupMinSize, w.NodeAutoScalingGroupDesiredCapacity, w.NodeAutoScalingGroupMaxSize
+
+	if min != nil && desired != nil && *min > *desired {
+		return errors.Errorf("spec.workerNodes: minimum size %d is greater than desired capacity %d", *min, *desired)
+	}
+	if desired != nil && max != nil && *desired > *max {
+		return errors.Errorf("spec.workerNodes: desired capacity %d is greater than maximum size %d", *desired, *max)
+	}
+	if min != nil && max != nil && *min > *max {
+		return errors.Errorf("spec.workerNodes: minimum size %d is greater than maximum size %d", *min, *max)
+	}
+	return nil
+}
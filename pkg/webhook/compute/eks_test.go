@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "github.com/crossplaneio/stack-aws/apis/compute/v1alpha2"
+)
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func TestValidate(t *testing.T) {
+	validRole := MapRole{RoleARN: "arn:aws:iam::000000000000:role/KubernetesAdmin", Username: "kubernetes-admin", Groups: []string{"system:masters"}}
+	validUser := MapUser{UserARN: "arn:aws:iam::000000000000:user/Alice", Username: "alice", Groups: []string{"system:masters"}}
+
+	cases := map[string]struct {
+		cluster *EKSCluster
+		wantErr bool
+	}{
+		"Valid": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", MapRoles: []MapRole{validRole}, MapUsers: []MapUser{validUser}}},
+			wantErr: false,
+		},
+		"MalformedRoleARN": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", MapRoles: []MapRole{{RoleARN: "not-an-arn"}}}},
+			wantErr: true,
+		},
+		"MalformedUserARN": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", MapUsers: []MapUser{{UserARN: "not-an-arn"}}}},
+			wantErr: true,
+		},
+		"UnsupportedRegion": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "mars-central-1"}},
+			wantErr: true,
+		},
+		"MinGreaterThanDesired": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", WorkerNodes: WorkerNodesSpec{
+				NodeAutoScalingGroupMinSize:         int64Ptr(5),
+				NodeAutoScalingGroupDesiredCapacity: int64Ptr(3),
+			}}},
+			wantErr: true,
+		},
+		"DesiredGreaterThanMax": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", WorkerNodes: WorkerNodesSpec{
+				NodeAutoScalingGroupDesiredCapacity: int64Ptr(5),
+				NodeAutoScalingGroupMaxSize:         int64Ptr(3),
+			}}},
+			wantErr: true,
+		},
+		"ConsistentSizing": {
+			cluster: &EKSCluster{Spec: EKSClusterSpec{Region: "us-east-1", WorkerNodes: WorkerNodesSpec{
+				NodeAutoScalingGroupMinSize:         int64Ptr(1),
+				NodeAutoScalingGroupDesiredCapacity: int64Ptr(3),
+				NodeAutoScalingGroupMaxSize:         int64Ptr(5),
+			}}},
+			wantErr: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validate(tc.cluster)
+			if tc.wantErr && err == nil {
+				t.Errorf("validate(...): expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("validate(...): %s", err)
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookConfiguration(t *testing.T) {
+	got := ValidatingWebhookConfiguration([]byte("fake-ca-bundle"), "crossplane-system", "stack-aws")
+
+	if got.Name != webhookConfigurationName {
+		t.Errorf("ValidatingWebhookConfiguration(...).Name = %q, want %q", got.Name, webhookConfigurationName)
+	}
+	if len(got.Webhooks) != 1 {
+		t.Fatalf("ValidatingWebhookConfiguration(...).Webhooks: expected exactly one Webhook, got %d", len(got.Webhooks))
+	}
+
+	wh := got.Webhooks[0]
+	if diff := cmp.Diff("crossplane-system", wh.ClientConfig.Service.Namespace); diff != "" {
+		t.Errorf("ClientConfig.Service.Namespace: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff("stack-aws", wh.ClientConfig.Service.Name); diff != "" {
+		t.Errorf("ClientConfig.Service.Name: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(webhookPath, *wh.ClientConfig.Service.Path); diff != "" {
+		t.Errorf("ClientConfig.Service.Path: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]byte("fake-ca-bundle"), wh.ClientConfig.CABundle); diff != "" {
+		t.Errorf("ClientConfig.CABundle: -want, +got:\n%s", diff)
+	}
+	if len(wh.Rules) != 1 || wh.Rules[0].Resources[0] != "eksclusters" {
+		t.Errorf("Rules: expected a single rule matching eksclusters, got %+v", wh.Rules)
+	}
+}